@@ -0,0 +1,184 @@
+// Package helpers provides test utilities for parsing Terraform plan output
+package helpers
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+)
+
+// SubnetKind identifies the role a planned subnet plays within the VPC
+type SubnetKind string
+
+const (
+	SubnetKindPublic  SubnetKind = "public"
+	SubnetKindPrivate SubnetKind = "private"
+)
+
+// SubnetPlan is one deterministically-allocated subnet: an AZ/kind pair plus
+// its IPv4 sub-CIDR and, when dual-stack is enabled, its IPv6 /64.
+type SubnetPlan struct {
+	AZ       string
+	Kind     SubnetKind
+	IPv4CIDR string
+	IPv6CIDR string
+}
+
+// CIDRPlanner deterministically allocates non-overlapping IPv4 sub-CIDRs and,
+// when dual-stack is enabled, IPv6 /64s across a VPC's availability zones,
+// using the same bit-shift subnetting apparentlymart/go-cidr's cidrsubnet()
+// implements so the Terraform module and its tests compute identical values.
+type CIDRPlanner struct {
+	VPCCIDR    string
+	IPv6CIDR   string // the VPC's /56; empty when dual-stack is disabled
+	EnableIPv6 bool
+}
+
+// NewCIDRPlanner constructs a planner for the given VPC IPv4 CIDR, optionally
+// enabling IPv6 /64 allocation from the given VPC /56.
+func NewCIDRPlanner(vpcCIDR, ipv6CIDR string, enableIPv6 bool) *CIDRPlanner {
+	return &CIDRPlanner{VPCCIDR: vpcCIDR, IPv6CIDR: ipv6CIDR, EnableIPv6: enableIPv6}
+}
+
+// Plan allocates one public and one private subnet per availability zone,
+// carving IPv4 sub-CIDRs via bit-shift subnetting and, when enabled, IPv6 /64s
+// from the VPC's /56. Allocation order is AZ-major, kind-minor (public then
+// private), so the same inputs always produce the same plan.
+func (p *CIDRPlanner) Plan(azs []string) ([]SubnetPlan, error) {
+	_, vpcNet, err := net.ParseCIDR(p.VPCCIDR)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VPC CIDR %q: %w", p.VPCCIDR, err)
+	}
+
+	var ipv6Net *net.IPNet
+	if p.EnableIPv6 {
+		_, ipv6Net, err = net.ParseCIDR(p.IPv6CIDR)
+		if err != nil {
+			return nil, fmt.Errorf("invalid VPC IPv6 CIDR %q: %w", p.IPv6CIDR, err)
+		}
+	}
+
+	kinds := []SubnetKind{SubnetKindPublic, SubnetKindPrivate}
+	newBits := subnetNewBits(len(azs) * len(kinds))
+
+	plans := make([]SubnetPlan, 0, len(azs)*len(kinds))
+	index := 0
+	for _, az := range azs {
+		for _, kind := range kinds {
+			ipv4, err := cidrSubnet(vpcNet, newBits, index)
+			if err != nil {
+				return nil, fmt.Errorf("allocating subnet %d (%s/%s): %w", index, az, kind, err)
+			}
+
+			plan := SubnetPlan{AZ: az, Kind: kind, IPv4CIDR: ipv4.String()}
+			if p.EnableIPv6 {
+				ipv6, err := cidrSubnetIPv6(ipv6Net, index)
+				if err != nil {
+					return nil, fmt.Errorf("allocating IPv6 subnet %d (%s/%s): %w", index, az, kind, err)
+				}
+				plan.IPv6CIDR = ipv6.String()
+			}
+			plans = append(plans, plan)
+			index++
+		}
+	}
+	return plans, nil
+}
+
+// subnetNewBits returns the number of additional prefix bits needed to carve
+// at least `count` equally-sized subnets out of a base CIDR.
+func subnetNewBits(count int) int {
+	newBits := 0
+	for (1 << uint(newBits)) < count {
+		newBits++
+	}
+	return newBits
+}
+
+// cidrSubnet returns the `index`-th subnet of `base` after extending its
+// prefix by `newBits`, following the same bit-shift subnetting
+// apparentlymart/go-cidr's cidrsubnet() function implements.
+func cidrSubnet(base *net.IPNet, newBits, index int) (*net.IPNet, error) {
+	ones, bits := base.Mask.Size()
+	newPrefix := ones + newBits
+	if newPrefix > bits {
+		return nil, fmt.Errorf("not enough address space for %d new bits in %s", newBits, base.String())
+	}
+	if index < 0 || index >= (1<<uint(newBits)) {
+		return nil, fmt.Errorf("index %d out of range for %d new bits", index, newBits)
+	}
+
+	ip := make(net.IP, len(base.IP))
+	copy(ip, base.IP)
+
+	ipInt := new(big.Int).SetBytes(ip)
+	shift := uint(bits - newPrefix)
+	ipInt.Or(ipInt, new(big.Int).Lsh(big.NewInt(int64(index)), shift))
+
+	raw := ipInt.Bytes()
+	padded := make(net.IP, len(ip))
+	copy(padded[len(padded)-len(raw):], raw)
+
+	return &net.IPNet{IP: padded, Mask: net.CIDRMask(newPrefix, bits)}, nil
+}
+
+// cidrSubnetIPv6 carves the `index`-th /64 out of a VPC's /56, the fixed
+// allocation size AWS hands out for IPv6 subnets.
+func cidrSubnetIPv6(base *net.IPNet, index int) (*net.IPNet, error) {
+	ones, _ := base.Mask.Size()
+	return cidrSubnet(base, 64-ones, index)
+}
+
+// AllocateVPCCIDRs deterministically carves `count` non-overlapping VPC-level
+// CIDRs out of a larger supernet, using the same bit-shift subnetting Plan
+// uses for subnets — so a multi-VPC topology gets guaranteed non-overlapping
+// VPC CIDRs without needing its own allocation scheme.
+func AllocateVPCCIDRs(superCIDR string, count int) ([]string, error) {
+	_, superNet, err := net.ParseCIDR(superCIDR)
+	if err != nil {
+		return nil, fmt.Errorf("invalid supernet CIDR %q: %w", superCIDR, err)
+	}
+
+	newBits := subnetNewBits(count)
+	cidrs := make([]string, count)
+	for i := 0; i < count; i++ {
+		sub, err := cidrSubnet(superNet, newBits, i)
+		if err != nil {
+			return nil, fmt.Errorf("allocating VPC CIDR %d: %w", i, err)
+		}
+		cidrs[i] = sub.String()
+	}
+	return cidrs, nil
+}
+
+// ShrinkageResult reports the subnets a replan would destroy because the new
+// VPC CIDR can no longer allocate them — the signal a Terraform plan surfaces
+// as a destructive (not in-place) resize.
+type ShrinkageResult struct {
+	Destructive bool
+	Dropped     []SubnetPlan
+}
+
+// DetectShrinkage compares a previous subnet layout against a replan and
+// flags the subnets that would be dropped, mirroring the shrinkage-detection
+// logic the GCP subnetwork provider uses to block CIDR changes that would
+// orphan existing resources. A subnet counts as dropped not only when its
+// AZ/kind pair is absent from the replan but also when it's still present
+// with a different IPv4 or IPv6 CIDR — Terraform would replace, not update,
+// that subnet in place.
+func DetectShrinkage(previous, next []SubnetPlan) ShrinkageResult {
+	byKey := make(map[string]SubnetPlan, len(next))
+	for _, s := range next {
+		byKey[s.AZ+"/"+string(s.Kind)] = s
+	}
+
+	var dropped []SubnetPlan
+	for _, s := range previous {
+		replan, stillPresent := byKey[s.AZ+"/"+string(s.Kind)]
+		if !stillPresent || replan.IPv4CIDR != s.IPv4CIDR || replan.IPv6CIDR != s.IPv6CIDR {
+			dropped = append(dropped, s)
+		}
+	}
+
+	return ShrinkageResult{Destructive: len(dropped) > 0, Dropped: dropped}
+}
@@ -1,98 +1,185 @@
-// Package helpers provides test utilities for parsing Terraform plan output
-package helpers
-
-import (
-	"encoding/json"
-	"strings"
-)
-
-// TerraformPlan represents a parsed Terraform plan
-type TerraformPlan struct {
-	PlannedValues PlannedValues `json:"planned_values"`
-	ResourceChanges []ResourceChange `json:"resource_changes"`
-}
-
-// PlannedValues contains the planned resource values
-type PlannedValues struct {
-	RootModule RootModule `json:"root_module"`
-}
-
-// RootModule contains resources in the root module
-type RootModule struct {
-	Resources []PlannedResource `json:"resources"`
-}
-
-// PlannedResource represents a planned resource
-type PlannedResource struct {
-	Address string                 `json:"address"`
-	Type    string                 `json:"type"`
-	Name    string                 `json:"name"`
-	Values  map[string]interface{} `json:"values"`
-}
-
-// ResourceChange represents a resource change in the plan
-type ResourceChange struct {
-	Address      string `json:"address"`
-	Type         string `json:"type"`
-	Name         string `json:"name"`
-	Change       Change `json:"change"`
-}
-
-// Change represents the change details
-type Change struct {
-	Actions []string               `json:"actions"`
-	Before  map[string]interface{} `json:"before"`
-	After   map[string]interface{} `json:"after"`
-}
-
-// ParseTerraformPlan parses JSON plan output into a TerraformPlan struct
-func ParseTerraformPlan(planJSON string) (*TerraformPlan, error) {
-	var plan TerraformPlan
-	err := json.Unmarshal([]byte(planJSON), &plan)
-	if err != nil {
-		return nil, err
-	}
-	return &plan, nil
-}
-
-// GetResourcesByType returns all resources of a specific type from the plan
-func (p *TerraformPlan) GetResourcesByType(resourceType string) []PlannedResource {
-	var resources []PlannedResource
-	for _, r := range p.PlannedValues.RootModule.Resources {
-		if r.Type == resourceType {
-			resources = append(resources, r)
-		}
-	}
-	return resources
-}
-
-// HasResourceType checks if the plan contains a resource of the given type
-func (p *TerraformPlan) HasResourceType(resourceType string) bool {
-	return len(p.GetResourcesByType(resourceType)) > 0
-}
-
-// GetResourceValue gets a specific value from a resource
-func (r *PlannedResource) GetResourceValue(key string) interface{} {
-	return r.Values[key]
-}
-
-// GetStringValue gets a string value from a resource
-func (r *PlannedResource) GetStringValue(key string) string {
-	if val, ok := r.Values[key].(string); ok {
-		return val
-	}
-	return ""
-}
-
-// GetBoolValue gets a boolean value from a resource
-func (r *PlannedResource) GetBoolValue(key string) bool {
-	if val, ok := r.Values[key].(bool); ok {
-		return val
-	}
-	return false
-}
-
-// ContainsSubstring checks if a string contains a substring
-func ContainsSubstring(s, substr string) bool {
-	return strings.Contains(s, substr)
-}
+// Package helpers provides test utilities for parsing Terraform plan output
+package helpers
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// TerraformPlan represents a parsed Terraform plan
+type TerraformPlan struct {
+	PlannedValues   PlannedValues    `json:"planned_values"`
+	ResourceChanges []ResourceChange `json:"resource_changes"`
+}
+
+// PlannedValues contains the planned resource values
+type PlannedValues struct {
+	RootModule RootModule `json:"root_module"`
+}
+
+// RootModule contains resources in the root module
+type RootModule struct {
+	Resources []PlannedResource `json:"resources"`
+}
+
+// PlannedResource represents a planned resource
+type PlannedResource struct {
+	Address string                 `json:"address"`
+	Type    string                 `json:"type"`
+	Name    string                 `json:"name"`
+	Values  map[string]interface{} `json:"values"`
+}
+
+// ResourceChange represents a resource change in the plan
+type ResourceChange struct {
+	Address string `json:"address"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Change  Change `json:"change"`
+}
+
+// Change represents the change details
+type Change struct {
+	Actions []string               `json:"actions"`
+	Before  map[string]interface{} `json:"before"`
+	After   map[string]interface{} `json:"after"`
+}
+
+// ChangeAction classifies a ResourceChange by its Actions, matching the
+// vocabulary `terraform show -json` uses for the action list
+type ChangeAction string
+
+const (
+	ActionNoOp    ChangeAction = "no-op"
+	ActionCreate  ChangeAction = "create"
+	ActionUpdate  ChangeAction = "update"
+	ActionDelete  ChangeAction = "delete"
+	ActionReplace ChangeAction = "replace" // "create+delete" or "delete+create"
+	ActionRead    ChangeAction = "read"
+)
+
+// Classify maps the raw Actions list onto a single ChangeAction
+func (c Change) Classify() ChangeAction {
+	switch len(c.Actions) {
+	case 0:
+		return ActionNoOp
+	case 1:
+		switch c.Actions[0] {
+		case "create":
+			return ActionCreate
+		case "update":
+			return ActionUpdate
+		case "delete":
+			return ActionDelete
+		case "read":
+			return ActionRead
+		default:
+			return ActionNoOp
+		}
+	default:
+		return ActionReplace
+	}
+}
+
+// ParseTerraformPlan parses JSON plan output into a TerraformPlan struct
+func ParseTerraformPlan(planJSON string) (*TerraformPlan, error) {
+	var plan TerraformPlan
+	err := json.Unmarshal([]byte(planJSON), &plan)
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// IsEmpty reports whether every resource change in the plan is a no-op, i.e.
+// re-planning against the current state would produce no changes at all
+func (p *TerraformPlan) IsEmpty() bool {
+	for _, rc := range p.ResourceChanges {
+		if rc.Change.Classify() != ActionNoOp {
+			return false
+		}
+	}
+	return true
+}
+
+// CreatesOnly reports whether every resource change in the plan is either a
+// no-op or a create, i.e. the plan only adds resources and never updates,
+// deletes, or replaces anything that already exists
+func (p *TerraformPlan) CreatesOnly() bool {
+	for _, rc := range p.ResourceChanges {
+		switch rc.Change.Classify() {
+		case ActionNoOp, ActionCreate:
+			continue
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Replacements returns the resource changes the plan would satisfy by
+// destroying and recreating the resource, rather than updating it in place
+func (p *TerraformPlan) Replacements() []ResourceChange {
+	var replacements []ResourceChange
+	for _, rc := range p.ResourceChanges {
+		if rc.Change.Classify() == ActionReplace {
+			replacements = append(replacements, rc)
+		}
+	}
+	return replacements
+}
+
+// DiffFor returns the before/after values and replace status for the resource
+// change at the given address. A nil before and after indicates no change
+// exists for that address.
+func (p *TerraformPlan) DiffFor(address string) (before, after map[string]interface{}, replaced bool) {
+	for _, rc := range p.ResourceChanges {
+		if rc.Address != address {
+			continue
+		}
+		return rc.Change.Before, rc.Change.After, rc.Change.Classify() == ActionReplace
+	}
+	return nil, nil, false
+}
+
+// GetResourcesByType returns all resources of a specific type from the plan
+func (p *TerraformPlan) GetResourcesByType(resourceType string) []PlannedResource {
+	var resources []PlannedResource
+	for _, r := range p.PlannedValues.RootModule.Resources {
+		if r.Type == resourceType {
+			resources = append(resources, r)
+		}
+	}
+	return resources
+}
+
+// HasResourceType checks if the plan contains a resource of the given type
+func (p *TerraformPlan) HasResourceType(resourceType string) bool {
+	return len(p.GetResourcesByType(resourceType)) > 0
+}
+
+// GetResourceValue gets a specific value from a resource
+func (r *PlannedResource) GetResourceValue(key string) interface{} {
+	return r.Values[key]
+}
+
+// GetStringValue gets a string value from a resource
+func (r *PlannedResource) GetStringValue(key string) string {
+	if val, ok := r.Values[key].(string); ok {
+		return val
+	}
+	return ""
+}
+
+// GetBoolValue gets a boolean value from a resource
+func (r *PlannedResource) GetBoolValue(key string) bool {
+	if val, ok := r.Values[key].(bool); ok {
+		return val
+	}
+	return false
+}
+
+// ContainsSubstring checks if a string contains a substring
+func ContainsSubstring(s, substr string) bool {
+	return strings.Contains(s, substr)
+}
@@ -1,622 +1,1959 @@
-// Package properties contains property-based tests for Terraform modules
-// These tests validate correctness properties defined in the design document
-package properties
-
-import (
-	"encoding/json"
-	"fmt"
-	"os"
-	"os/exec"
-	"path/filepath"
-	"testing"
-
-	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
-	"pgregory.net/rapid"
-)
-
-// ECSServiceConfig represents a valid ECS service module configuration
-type ECSServiceConfig struct {
-	Environment                      string
-	ProjectName                      string
-	ServiceName                      string
-	ServiceType                      string
-	ClusterARN                       string
-	ClusterName                      string
-	TaskDefinitionARN                string
-	ContainerName                    string
-	ContainerPort                    int
-	DesiredCount                     int
-	DeploymentMinimumHealthyPercent  int
-	DeploymentMaximumPercent         int
-	PrivateSubnetIDs                 []string
-	SecurityGroupIDs                 []string
-	TargetGroupARN                   string
-	EnableServiceDiscovery           bool
-	ServiceDiscoveryNamespaceID      string
-}
-
-// genServiceType generates a valid service type
-func genServiceType() *rapid.Generator[string] {
-	return rapid.SampledFrom([]string{"public", "internal"})
-}
-
-// genServiceName generates a valid service name
-func genServiceName() *rapid.Generator[string] {
-	return rapid.Custom(func(t *rapid.T) string {
-		prefixes := []string{"api", "web", "worker", "processor", "gateway", "auth", "data"}
-		suffixes := []string{"service", "svc", "app", "handler"}
-		prefix := rapid.SampledFrom(prefixes).Draw(t, "prefix")
-		suffix := rapid.SampledFrom(suffixes).Draw(t, "suffix")
-		num := rapid.IntRange(1, 99).Draw(t, "num")
-		return fmt.Sprintf("%s-%s-%d", prefix, suffix, num)
-	})
-}
-
-// genDesiredCount generates a valid desired count (1-10)
-func genDesiredCount() *rapid.Generator[int] {
-	return rapid.IntRange(1, 10)
-}
-
-// genContainerPort generates a valid container port
-func genContainerPort() *rapid.Generator[int] {
-	return rapid.SampledFrom([]int{80, 443, 3000, 8080, 8443, 9000})
-}
-
-// genDeploymentMinHealthyPercent generates a valid minimum healthy percent
-func genDeploymentMinHealthyPercent() *rapid.Generator[int] {
-	return rapid.SampledFrom([]int{50, 100})
-}
-
-// genDeploymentMaxPercent generates a valid maximum percent
-func genDeploymentMaxPercent() *rapid.Generator[int] {
-	return rapid.SampledFrom([]int{150, 200})
-}
-
-
-// genPrivateSubnetIDs generates mock private subnet IDs
-func genPrivateSubnetIDs() *rapid.Generator[[]string] {
-	return rapid.SampledFrom([][]string{
-		{"subnet-private-1a", "subnet-private-1b"},
-		{"subnet-private-1a", "subnet-private-1b", "subnet-private-1c"},
-	})
-}
-
-// genSecurityGroupIDs generates mock security group IDs
-func genSecurityGroupIDs() *rapid.Generator[[]string] {
-	return rapid.Custom(func(t *rapid.T) []string {
-		count := rapid.IntRange(1, 3).Draw(t, "sg_count")
-		sgs := make([]string, count)
-		for i := 0; i < count; i++ {
-			sgs[i] = fmt.Sprintf("sg-%d", rapid.IntRange(100000, 999999).Draw(t, fmt.Sprintf("sg_%d", i)))
-		}
-		return sgs
-	})
-}
-
-// genECSServiceConfig generates a valid ECS service configuration
-func genECSServiceConfig() *rapid.Generator[ECSServiceConfig] {
-	return rapid.Custom(func(t *rapid.T) ECSServiceConfig {
-		serviceType := genServiceType().Draw(t, "service_type")
-		serviceName := genServiceName().Draw(t, "service_name")
-		env := genEnvironment().Draw(t, "environment")
-		projectName := genProjectName().Draw(t, "project_name")
-
-		config := ECSServiceConfig{
-			Environment:                     env,
-			ProjectName:                     projectName,
-			ServiceName:                     serviceName,
-			ServiceType:                     serviceType,
-			ClusterARN:                      fmt.Sprintf("arn:aws:ecs:us-east-1:123456789012:cluster/%s-%s-cluster", projectName, env),
-			ClusterName:                     fmt.Sprintf("%s-%s-cluster", projectName, env),
-			TaskDefinitionARN:               fmt.Sprintf("arn:aws:ecs:us-east-1:123456789012:task-definition/%s-%s:1", serviceName, env),
-			ContainerName:                   serviceName,
-			ContainerPort:                   genContainerPort().Draw(t, "container_port"),
-			DesiredCount:                    genDesiredCount().Draw(t, "desired_count"),
-			DeploymentMinimumHealthyPercent: genDeploymentMinHealthyPercent().Draw(t, "min_healthy"),
-			DeploymentMaximumPercent:        genDeploymentMaxPercent().Draw(t, "max_percent"),
-			PrivateSubnetIDs:                genPrivateSubnetIDs().Draw(t, "private_subnets"),
-			SecurityGroupIDs:                genSecurityGroupIDs().Draw(t, "security_groups"),
-		}
-
-		// Set service-type specific configurations
-		if serviceType == "public" {
-			config.TargetGroupARN = fmt.Sprintf("arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/%s-%s/1234567890", serviceName, env)
-			config.EnableServiceDiscovery = false
-		} else {
-			config.TargetGroupARN = ""
-			config.EnableServiceDiscovery = rapid.Bool().Draw(t, "enable_service_discovery")
-			if config.EnableServiceDiscovery {
-				config.ServiceDiscoveryNamespaceID = fmt.Sprintf("ns-%s", env)
-			}
-		}
-
-		return config
-	})
-}
-
-// toTerraformVars converts ECSServiceConfig to Terraform variables map
-func (c ECSServiceConfig) toTerraformVars() map[string]interface{} {
-	vars := map[string]interface{}{
-		"environment":                        c.Environment,
-		"project_name":                       c.ProjectName,
-		"service_name":                       c.ServiceName,
-		"service_type":                       c.ServiceType,
-		"cluster_arn":                        c.ClusterARN,
-		"cluster_name":                       c.ClusterName,
-		"task_definition_arn":                c.TaskDefinitionARN,
-		"container_name":                     c.ContainerName,
-		"container_port":                     c.ContainerPort,
-		"desired_count":                      c.DesiredCount,
-		"deployment_minimum_healthy_percent": c.DeploymentMinimumHealthyPercent,
-		"deployment_maximum_percent":         c.DeploymentMaximumPercent,
-		"private_subnet_ids":                 c.PrivateSubnetIDs,
-		"security_group_ids":                 c.SecurityGroupIDs,
-		"enable_service_discovery":           c.EnableServiceDiscovery,
-	}
-
-	if c.ServiceType == "public" && c.TargetGroupARN != "" {
-		vars["target_group_arn"] = c.TargetGroupARN
-	}
-
-	if c.EnableServiceDiscovery && c.ServiceDiscoveryNamespaceID != "" {
-		vars["service_discovery_namespace_id"] = c.ServiceDiscoveryNamespaceID
-	}
-
-	return vars
-}
-
-
-// ECSServicePlanOutput represents the JSON output of terraform show -json for ECS service
-type ECSServicePlanOutput struct {
-	PlannedValues struct {
-		RootModule struct {
-			Resources []struct {
-				Address string                 `json:"address"`
-				Type    string                 `json:"type"`
-				Name    string                 `json:"name"`
-				Values  map[string]interface{} `json:"values"`
-			} `json:"resources"`
-		} `json:"root_module"`
-	} `json:"planned_values"`
-}
-
-// getResourcesByType returns all resources of a given type from the plan
-func (p *ECSServicePlanOutput) getResourcesByType(resourceType string) []map[string]interface{} {
-	var resources []map[string]interface{}
-	for _, r := range p.PlannedValues.RootModule.Resources {
-		if r.Type == resourceType {
-			resources = append(resources, r.Values)
-		}
-	}
-	return resources
-}
-
-// getECSServiceModulePath returns the absolute path to the ecs-service module
-func getECSServiceModulePath(t *testing.T) string {
-	cwd, err := os.Getwd()
-	require.NoError(t, err)
-	modulePath := filepath.Join(cwd, "..", "..", "terraform", "modules", "ecs-service")
-	_, err = os.Stat(modulePath)
-	require.NoError(t, err, "Module path does not exist: %s", modulePath)
-	return modulePath
-}
-
-// runECSServiceTerraformPlan runs terraform plan and returns the JSON output
-func runECSServiceTerraformPlan(t *testing.T, modulePath string, config ECSServiceConfig, planName string) *ECSServicePlanOutput {
-	// Create a temporary directory for the test
-	tempDir, err := os.MkdirTemp("", "terraform-test-*")
-	require.NoError(t, err)
-	defer os.RemoveAll(tempDir)
-
-	// Create a test configuration file that uses the module
-	tfConfig := fmt.Sprintf(`
-terraform {
-  required_providers {
-    aws = {
-      source  = "hashicorp/aws"
-      version = ">= 5.0.0"
-    }
-  }
-}
-
-provider "aws" {
-  region = "us-east-1"
-  skip_credentials_validation = true
-  skip_metadata_api_check     = true
-  skip_requesting_account_id  = true
-  access_key                  = "mock_access_key"
-  secret_key                  = "mock_secret_key"
-}
-
-module "ecs_service" {
-  source = "%s"
-
-  environment   = "%s"
-  project_name  = "%s"
-  service_name  = "%s"
-  service_type  = "%s"
-
-  cluster_arn         = "%s"
-  cluster_name        = "%s"
-  task_definition_arn = "%s"
-  container_name      = "%s"
-  container_port      = %d
-
-  desired_count                      = %d
-  deployment_minimum_healthy_percent = %d
-  deployment_maximum_percent         = %d
-
-  private_subnet_ids = %s
-  security_group_ids = %s
-
-  target_group_arn = %s
-
-  enable_service_discovery       = %t
-  service_discovery_namespace_id = %s
-}
-`,
-		modulePath,
-		config.Environment,
-		config.ProjectName,
-		config.ServiceName,
-		config.ServiceType,
-		config.ClusterARN,
-		config.ClusterName,
-		config.TaskDefinitionARN,
-		config.ContainerName,
-		config.ContainerPort,
-		config.DesiredCount,
-		config.DeploymentMinimumHealthyPercent,
-		config.DeploymentMaximumPercent,
-		toHCLList(config.PrivateSubnetIDs),
-		toHCLList(config.SecurityGroupIDs),
-		toHCLString(config.TargetGroupARN),
-		config.EnableServiceDiscovery,
-		toHCLString(config.ServiceDiscoveryNamespaceID),
-	)
-
-	// Write the test configuration
-	testConfigPath := filepath.Join(tempDir, "main.tf")
-	err = os.WriteFile(testConfigPath, []byte(tfConfig), 0644)
-	require.NoError(t, err)
-
-	// Initialize Terraform
-	initCmd := exec.Command("terraform", "init")
-	initCmd.Dir = tempDir
-	initOutput, err := initCmd.CombinedOutput()
-	require.NoError(t, err, "Failed to init terraform: %s", string(initOutput))
-
-	// Create plan
-	planFilePath := filepath.Join(tempDir, "plan.tfplan")
-	planCmd := exec.Command("terraform", "plan", "-out="+planFilePath, "-input=false")
-	planCmd.Dir = tempDir
-	planOutput, err := planCmd.CombinedOutput()
-	require.NoError(t, err, "Failed to create terraform plan: %s", string(planOutput))
-
-	// Get JSON output
-	showCmd := exec.Command("terraform", "show", "-json", planFilePath)
-	showCmd.Dir = tempDir
-	jsonOutput, err := showCmd.CombinedOutput()
-	require.NoError(t, err, "Failed to show terraform plan: %s", string(jsonOutput))
-
-	// Parse JSON
-	var plan ECSServicePlanOutput
-	err = json.Unmarshal(jsonOutput, &plan)
-	require.NoError(t, err, "Failed to parse terraform plan JSON")
-
-	return &plan
-}
-
-// toHCLList converts a string slice to HCL list format
-func toHCLList(items []string) string {
-	if len(items) == 0 {
-		return "[]"
-	}
-	result := "["
-	for i, item := range items {
-		if i > 0 {
-			result += ", "
-		}
-		result += fmt.Sprintf(`"%s"`, item)
-	}
-	result += "]"
-	return result
-}
-
-// toHCLString converts a string to HCL string format (handles null)
-func toHCLString(s string) string {
-	if s == "" {
-		return "null"
-	}
-	return fmt.Sprintf(`"%s"`, s)
-}
-
-
-// Feature: ecs-fargate-cicd-infrastructure, Property 21: ECS service per microservice
-// *For any* service configuration, exactly one ECS service should be created with a unique name
-// **Validates: Requirements 5.1**
-func TestProperty21_ECSServicePerMicroservice(t *testing.T) {
-	t.Parallel()
-
-	rapid.Check(t, func(rt *rapid.T) {
-		config := genECSServiceConfig().Draw(rt, "config")
-
-		modulePath := getECSServiceModulePath(t)
-		planName := fmt.Sprintf("plan-p21-%s-%s", config.ServiceName, config.Environment)
-		plan := runECSServiceTerraformPlan(t, modulePath, config, planName)
-
-		// Property 21.1: Exactly one ECS service should be created
-		ecsServices := plan.getResourcesByType("aws_ecs_service")
-		assert.Len(t, ecsServices, 1, "Exactly one ECS service should be created per module invocation")
-
-		if len(ecsServices) > 0 {
-			service := ecsServices[0]
-
-			// Property 21.2: Service name should be unique and include service name and environment
-			serviceName, ok := service["name"].(string)
-			assert.True(t, ok, "Service should have a name")
-			assert.Contains(t, serviceName, config.ServiceName, "Service name should contain the service name")
-			assert.Contains(t, serviceName, config.Environment, "Service name should contain the environment")
-
-			// Property 21.3: Service should be associated with the correct cluster
-			clusterARN, ok := service["cluster"].(string)
-			assert.True(t, ok, "Service should have a cluster")
-			assert.Equal(t, config.ClusterARN, clusterARN, "Service should be in the correct cluster")
-		}
-	})
-}
-
-// Feature: ecs-fargate-cicd-infrastructure, Property 24: Desired count configuration
-// *For any* ECS service configuration, desired_count should be set to a positive integer
-// **Validates: Requirements 5.4**
-func TestProperty24_DesiredCountConfiguration(t *testing.T) {
-	t.Parallel()
-
-	rapid.Check(t, func(rt *rapid.T) {
-		config := genECSServiceConfig().Draw(rt, "config")
-
-		modulePath := getECSServiceModulePath(t)
-		planName := fmt.Sprintf("plan-p24-%s-%s", config.ServiceName, config.Environment)
-		plan := runECSServiceTerraformPlan(t, modulePath, config, planName)
-
-		// Property 24.1: ECS service should have desired_count set
-		ecsServices := plan.getResourcesByType("aws_ecs_service")
-		require.Len(t, ecsServices, 1, "Exactly one ECS service should be created")
-
-		service := ecsServices[0]
-
-		// Property 24.2: Desired count should match the input configuration
-		desiredCount, ok := service["desired_count"].(float64)
-		assert.True(t, ok, "Service should have desired_count set")
-		assert.Equal(t, float64(config.DesiredCount), desiredCount, "Desired count should match input")
-
-		// Property 24.3: Desired count should be a positive integer
-		assert.GreaterOrEqual(t, desiredCount, float64(1), "Desired count should be at least 1")
-	})
-}
-
-// Feature: ecs-fargate-cicd-infrastructure, Property 25: Rolling update configuration
-// *For any* ECS service deployment configuration, both minimum_healthy_percent and maximum_percent should be defined with valid values
-// **Validates: Requirements 5.5**
-func TestProperty25_RollingUpdateConfiguration(t *testing.T) {
-	t.Parallel()
-
-	rapid.Check(t, func(rt *rapid.T) {
-		config := genECSServiceConfig().Draw(rt, "config")
-
-		modulePath := getECSServiceModulePath(t)
-		planName := fmt.Sprintf("plan-p25-%s-%s", config.ServiceName, config.Environment)
-		plan := runECSServiceTerraformPlan(t, modulePath, config, planName)
-
-		// Property 25.1: ECS service should have deployment configuration
-		ecsServices := plan.getResourcesByType("aws_ecs_service")
-		require.Len(t, ecsServices, 1, "Exactly one ECS service should be created")
-
-		service := ecsServices[0]
-
-		// Property 25.2: Deployment configuration should be present
-		deploymentConfig, ok := service["deployment_configuration"].([]interface{})
-		assert.True(t, ok && len(deploymentConfig) > 0, "Service should have deployment_configuration")
-
-		if len(deploymentConfig) > 0 {
-			config := deploymentConfig[0].(map[string]interface{})
-
-			// Property 25.3: minimum_healthy_percent should be defined
-			minHealthy, hasMinHealthy := config["minimum_healthy_percent"]
-			assert.True(t, hasMinHealthy, "Deployment configuration should have minimum_healthy_percent")
-			if hasMinHealthy {
-				minHealthyVal, ok := minHealthy.(float64)
-				assert.True(t, ok, "minimum_healthy_percent should be a number")
-				assert.GreaterOrEqual(t, minHealthyVal, float64(0), "minimum_healthy_percent should be >= 0")
-				assert.LessOrEqual(t, minHealthyVal, float64(200), "minimum_healthy_percent should be <= 200")
-			}
-
-			// Property 25.4: maximum_percent should be defined
-			maxPercent, hasMaxPercent := config["maximum_percent"]
-			assert.True(t, hasMaxPercent, "Deployment configuration should have maximum_percent")
-			if hasMaxPercent {
-				maxPercentVal, ok := maxPercent.(float64)
-				assert.True(t, ok, "maximum_percent should be a number")
-				assert.GreaterOrEqual(t, maxPercentVal, float64(100), "maximum_percent should be >= 100")
-				assert.LessOrEqual(t, maxPercentVal, float64(400), "maximum_percent should be <= 400")
-			}
-		}
-	})
-}
-
-
-// Feature: ecs-fargate-cicd-infrastructure, Property 27: Private subnet placement
-// *For any* ECS service network configuration, all subnet IDs should reference private subnets (not public subnets)
-// **Validates: Requirements 5.7**
-func TestProperty27_PrivateSubnetPlacement(t *testing.T) {
-	t.Parallel()
-
-	rapid.Check(t, func(rt *rapid.T) {
-		config := genECSServiceConfig().Draw(rt, "config")
-
-		modulePath := getECSServiceModulePath(t)
-		planName := fmt.Sprintf("plan-p27-%s-%s", config.ServiceName, config.Environment)
-		plan := runECSServiceTerraformPlan(t, modulePath, config, planName)
-
-		// Property 27.1: ECS service should have network configuration
-		ecsServices := plan.getResourcesByType("aws_ecs_service")
-		require.Len(t, ecsServices, 1, "Exactly one ECS service should be created")
-
-		service := ecsServices[0]
-
-		// Property 27.2: Network configuration should be present
-		networkConfig, ok := service["network_configuration"].([]interface{})
-		assert.True(t, ok && len(networkConfig) > 0, "Service should have network_configuration")
-
-		if len(networkConfig) > 0 {
-			netConfig := networkConfig[0].(map[string]interface{})
-
-			// Property 27.3: Subnets should be configured
-			subnets, hasSubnets := netConfig["subnets"]
-			assert.True(t, hasSubnets, "Network configuration should have subnets")
-
-			if hasSubnets {
-				subnetList, ok := subnets.([]interface{})
-				assert.True(t, ok, "Subnets should be a list")
-				assert.GreaterOrEqual(t, len(subnetList), 1, "At least one subnet should be configured")
-
-				// Property 27.4: Subnets should match the private subnet IDs provided
-				for i, subnet := range subnetList {
-					subnetID, ok := subnet.(string)
-					assert.True(t, ok, "Subnet ID should be a string")
-					assert.Equal(t, config.PrivateSubnetIDs[i], subnetID, "Subnet should match input private subnet")
-				}
-			}
-
-			// Property 27.5: assign_public_ip should be false for private subnets
-			assignPublicIP, hasAssignPublicIP := netConfig["assign_public_ip"]
-			if hasAssignPublicIP {
-				assignPublicIPVal, ok := assignPublicIP.(bool)
-				assert.True(t, ok, "assign_public_ip should be a boolean")
-				assert.False(t, assignPublicIPVal, "assign_public_ip should be false for private subnet placement")
-			}
-		}
-	})
-}
-
-// Feature: ecs-fargate-cicd-infrastructure, Property 37: Target group attachment for public services
-// *For any* public-facing service, the ECS service should have a load_balancer block referencing a target group ARN
-// **Validates: Requirements 8.1**
-func TestProperty37_TargetGroupAttachmentForPublicServices(t *testing.T) {
-	t.Parallel()
-
-	rapid.Check(t, func(rt *rapid.T) {
-		config := genECSServiceConfig().Draw(rt, "config")
-
-		// Only test public services
-		if config.ServiceType != "public" {
-			return
-		}
-
-		modulePath := getECSServiceModulePath(t)
-		planName := fmt.Sprintf("plan-p37-%s-%s", config.ServiceName, config.Environment)
-		plan := runECSServiceTerraformPlan(t, modulePath, config, planName)
-
-		// Property 37.1: ECS service should exist
-		ecsServices := plan.getResourcesByType("aws_ecs_service")
-		require.Len(t, ecsServices, 1, "Exactly one ECS service should be created")
-
-		service := ecsServices[0]
-
-		// Property 37.2: Public service should have load_balancer configuration
-		loadBalancer, ok := service["load_balancer"].([]interface{})
-		assert.True(t, ok && len(loadBalancer) > 0, "Public service should have load_balancer configuration")
-
-		if len(loadBalancer) > 0 {
-			lbConfig := loadBalancer[0].(map[string]interface{})
-
-			// Property 37.3: Load balancer should reference the target group ARN
-			targetGroupARN, hasTargetGroup := lbConfig["target_group_arn"]
-			assert.True(t, hasTargetGroup, "Load balancer should have target_group_arn")
-			if hasTargetGroup {
-				tgARN, ok := targetGroupARN.(string)
-				assert.True(t, ok, "target_group_arn should be a string")
-				assert.Equal(t, config.TargetGroupARN, tgARN, "Target group ARN should match input")
-			}
-
-			// Property 37.4: Load balancer should reference the correct container
-			containerName, hasContainerName := lbConfig["container_name"]
-			assert.True(t, hasContainerName, "Load balancer should have container_name")
-			if hasContainerName {
-				cName, ok := containerName.(string)
-				assert.True(t, ok, "container_name should be a string")
-				assert.Equal(t, config.ContainerName, cName, "Container name should match input")
-			}
-
-			// Property 37.5: Load balancer should reference the correct container port
-			containerPort, hasContainerPort := lbConfig["container_port"]
-			assert.True(t, hasContainerPort, "Load balancer should have container_port")
-			if hasContainerPort {
-				cPort, ok := containerPort.(float64)
-				assert.True(t, ok, "container_port should be a number")
-				assert.Equal(t, float64(config.ContainerPort), cPort, "Container port should match input")
-			}
-		}
-
-		// Property 37.6: Public service should have health_check_grace_period_seconds set
-		healthCheckGrace, hasHealthCheckGrace := service["health_check_grace_period_seconds"]
-		assert.True(t, hasHealthCheckGrace, "Public service should have health_check_grace_period_seconds")
-		if hasHealthCheckGrace {
-			graceVal, ok := healthCheckGrace.(float64)
-			assert.True(t, ok, "health_check_grace_period_seconds should be a number")
-			assert.GreaterOrEqual(t, graceVal, float64(0), "health_check_grace_period_seconds should be >= 0")
-		}
-	})
-}
-
-// Feature: ecs-fargate-cicd-infrastructure, Property 42: No ALB for internal services
-// *For any* internal service configuration, the ECS service should not have a load_balancer block
-// **Validates: Requirements 8.7**
-func TestProperty42_NoALBForInternalServices(t *testing.T) {
-	t.Parallel()
-
-	rapid.Check(t, func(rt *rapid.T) {
-		config := genECSServiceConfig().Draw(rt, "config")
-
-		// Only test internal services
-		if config.ServiceType != "internal" {
-			return
-		}
-
-		modulePath := getECSServiceModulePath(t)
-		planName := fmt.Sprintf("plan-p42-%s-%s", config.ServiceName, config.Environment)
-		plan := runECSServiceTerraformPlan(t, modulePath, config, planName)
-
-		// Property 42.1: ECS service should exist
-		ecsServices := plan.getResourcesByType("aws_ecs_service")
-		require.Len(t, ecsServices, 1, "Exactly one ECS service should be created")
-
-		service := ecsServices[0]
-
-		// Property 42.2: Internal service should NOT have load_balancer configuration
-		loadBalancer, ok := service["load_balancer"].([]interface{})
-		if ok {
-			assert.Len(t, loadBalancer, 0, "Internal service should not have load_balancer configuration")
-		}
-
-		// Property 42.3: Internal service should NOT have health_check_grace_period_seconds
-		// (only services with load balancers need this)
-		healthCheckGrace, hasHealthCheckGrace := service["health_check_grace_period_seconds"]
-		if hasHealthCheckGrace {
-			// If present, it should be null or 0 for internal services
-			if healthCheckGrace != nil {
-				graceVal, ok := healthCheckGrace.(float64)
-				if ok {
-					assert.Equal(t, float64(0), graceVal, "Internal service should not have health_check_grace_period_seconds set")
-				}
-			}
-		}
-
-		// Property 42.4: If service discovery is enabled, service_registries should be present
-		if config.EnableServiceDiscovery {
-			serviceRegistries, ok := service["service_registries"].([]interface{})
-			assert.True(t, ok && len(serviceRegistries) > 0, "Internal service with service discovery should have service_registries")
-		}
-	})
-}
+// Package properties contains property-based tests for Terraform modules
+// These tests validate correctness properties defined in the design document
+package properties
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+// ECSServiceConfig represents a valid ECS service module configuration
+type ECSServiceConfig struct {
+	Environment                     string
+	ProjectName                     string
+	ServiceName                     string
+	ServiceType                     string
+	ClusterARN                      string
+	ClusterName                     string
+	TaskDefinitionARN               string
+	ContainerName                   string
+	ContainerPort                   int
+	DesiredCount                    int
+	DeploymentMinimumHealthyPercent int
+	DeploymentMaximumPercent        int
+	PrivateSubnetIDs                []string
+	SecurityGroupIDs                []string
+	TargetGroupARN                  string
+	EnableServiceDiscovery          bool
+	ServiceDiscoveryNamespaceID     string
+	ServiceConnect                  ServiceConnectConfig
+	DeploymentController            DeploymentController
+	DeploymentCircuitBreaker        *DeploymentCircuitBreaker
+	CapacityProviderStrategy        []CapacityProviderStrategyEntry
+	Autoscaling                     AutoscalingConfig
+	Partition                       string
+	Tags                            map[string]string
+	PropagateTags                   string
+	CreateTargetGroup               bool
+	ListenerARN                     string
+	HostHeaders                     []string
+	PathPatterns                    []string
+	HealthCheck                     ALBHealthCheckConfig
+	HealthCheckGracePeriodSeconds   *int // nil = provider default, 0 = explicitly disabled
+	ServiceRegistries               []ServiceRegistry
+	Namespace                       string // defaults to DefaultNamespace when not multi-tenant
+}
+
+// ServiceConnectPortMapping configures Service Connect for a single exposed port
+type ServiceConnectPortMapping struct {
+	PortName           string
+	DiscoveryName      string // empty means the provider defaults this to the container name
+	ClientAliasPort    int
+	ClientAliasDNSName string
+}
+
+// ServiceConnectConfig configures ECS Service Connect for a service, replacing
+// an ALB/Cloud Map service-registry entry with namespace-based discovery
+type ServiceConnectConfig struct {
+	Enabled      bool
+	NamespaceARN string
+	Mode         string // "client" (consume only) or "client-server" (also exposes a client_alias)
+	Services     []ServiceConnectPortMapping
+}
+
+// genServiceConnectConfig generates a Service Connect configuration for the given container/port
+func genServiceConnectConfig(env, containerName string, containerPort int) *rapid.Generator[ServiceConnectConfig] {
+	return rapid.Custom(func(t *rapid.T) ServiceConnectConfig {
+		mode := rapid.SampledFrom([]string{"client", "client-server"}).Draw(t, "service_connect_mode")
+		discoveryName := ""
+		if rapid.Bool().Draw(t, "override_discovery_name") {
+			discoveryName = fmt.Sprintf("%s-alias", containerName)
+		}
+
+		return ServiceConnectConfig{
+			Enabled:      true,
+			NamespaceARN: fmt.Sprintf("arn:aws:servicediscovery:us-east-1:123456789012:namespace/ns-%s", env),
+			Mode:         mode,
+			Services: []ServiceConnectPortMapping{
+				{
+					PortName:           fmt.Sprintf("%s-port", containerName),
+					DiscoveryName:      discoveryName,
+					ClientAliasPort:    containerPort,
+					ClientAliasDNSName: containerName,
+				},
+			},
+		}
+	})
+}
+
+// genServiceType generates a valid service type
+func genServiceType() *rapid.Generator[string] {
+	return rapid.SampledFrom([]string{"public", "internal"})
+}
+
+// genServiceName generates a valid service name
+func genServiceName() *rapid.Generator[string] {
+	return rapid.Custom(func(t *rapid.T) string {
+		prefixes := []string{"api", "web", "worker", "processor", "gateway", "auth", "data"}
+		suffixes := []string{"service", "svc", "app", "handler"}
+		prefix := rapid.SampledFrom(prefixes).Draw(t, "prefix")
+		suffix := rapid.SampledFrom(suffixes).Draw(t, "suffix")
+		num := rapid.IntRange(1, 99).Draw(t, "num")
+		return fmt.Sprintf("%s-%s-%d", prefix, suffix, num)
+	})
+}
+
+// genDesiredCount generates a valid desired count (1-10)
+func genDesiredCount() *rapid.Generator[int] {
+	return rapid.IntRange(1, 10)
+}
+
+// genContainerPort generates a valid container port
+func genContainerPort() *rapid.Generator[int] {
+	return rapid.SampledFrom([]int{80, 443, 3000, 8080, 8443, 9000})
+}
+
+// genDeploymentMinHealthyPercent generates a valid minimum healthy percent
+func genDeploymentMinHealthyPercent() *rapid.Generator[int] {
+	return rapid.SampledFrom([]int{50, 100})
+}
+
+// genDeploymentMaxPercent generates a valid maximum percent
+func genDeploymentMaxPercent() *rapid.Generator[int] {
+	return rapid.SampledFrom([]int{150, 200})
+}
+
+// DeploymentController selects the strategy the ECS service uses to manage deployments
+type DeploymentController struct {
+	Type string // "ECS", "CODE_DEPLOY", or "EXTERNAL"
+}
+
+// DeploymentCircuitBreaker configures automatic rollback of failed deployments.
+// Only supported when DeploymentController.Type == "ECS"
+type DeploymentCircuitBreaker struct {
+	Enable   bool
+	Rollback bool
+}
+
+// validateDeploymentControllerCompatibility fails fast when a circuit breaker is
+// paired with a deployment controller that doesn't support it, mirroring the
+// aws_ecs_service resource's own validation
+func validateDeploymentControllerCompatibility(controller DeploymentController, breaker *DeploymentCircuitBreaker) error {
+	if breaker != nil && controller.Type != "ECS" {
+		return fmt.Errorf("deployment_circuit_breaker is only supported with deployment_controller.type = ECS, got %q", controller.Type)
+	}
+	return nil
+}
+
+// genDeploymentController generates a valid deployment controller type
+func genDeploymentController() *rapid.Generator[DeploymentController] {
+	return rapid.Custom(func(t *rapid.T) DeploymentController {
+		return DeploymentController{
+			Type: rapid.SampledFrom([]string{"ECS", "CODE_DEPLOY", "EXTERNAL"}).Draw(t, "deployment_controller_type"),
+		}
+	})
+}
+
+// genDeploymentCircuitBreaker generates a circuit breaker configuration, only valid
+// alongside the ECS deployment controller
+func genDeploymentCircuitBreaker() *rapid.Generator[DeploymentCircuitBreaker] {
+	return rapid.Custom(func(t *rapid.T) DeploymentCircuitBreaker {
+		return DeploymentCircuitBreaker{
+			Enable:   rapid.Bool().Draw(t, "circuit_breaker_enable"),
+			Rollback: rapid.Bool().Draw(t, "circuit_breaker_rollback"),
+		}
+	})
+}
+
+// CapacityProviderStrategyEntry is one entry of an aws_ecs_service capacity_provider_strategy block
+type CapacityProviderStrategyEntry struct {
+	Provider string // "FARGATE" or "FARGATE_SPOT"
+	Weight   int
+	Base     int
+}
+
+// genCapacityProviderStrategy generates a valid capacity provider strategy mixing
+// FARGATE and FARGATE_SPOT, with exactly one entry carrying a non-zero base
+func genCapacityProviderStrategy() *rapid.Generator[[]CapacityProviderStrategyEntry] {
+	return rapid.Custom(func(t *rapid.T) []CapacityProviderStrategyEntry {
+		providers := rapid.SampledFrom([][]string{
+			{"FARGATE"},
+			{"FARGATE_SPOT"},
+			{"FARGATE", "FARGATE_SPOT"},
+		}).Draw(t, "capacity_providers")
+
+		baseIndex := rapid.IntRange(0, len(providers)-1).Draw(t, "base_index")
+		entries := make([]CapacityProviderStrategyEntry, len(providers))
+		for i, provider := range providers {
+			entries[i] = CapacityProviderStrategyEntry{
+				Provider: provider,
+				Weight:   rapid.IntRange(1, 4).Draw(t, fmt.Sprintf("weight_%d", i)),
+			}
+			if i == baseIndex {
+				entries[i].Base = rapid.IntRange(1, 4).Draw(t, "base")
+			}
+		}
+		return entries
+	})
+}
+
+// ServiceRegistry abstracts the backend an internal service registers itself with,
+// so the module isn't hard-wired to AWS Cloud Map
+type ServiceRegistry interface {
+	Kind() string
+}
+
+// DefaultNamespace is the module-level namespace used when a service doesn't opt
+// into multi-tenant namespace scoping
+const DefaultNamespace = "default"
+
+// genNamespace generates a tenant namespace segment, exercising the multi-tenant
+// path where two services with the same short name in different namespaces
+// must not collide
+func genNamespace() *rapid.Generator[string] {
+	return rapid.SampledFrom([]string{DefaultNamespace, "team-a", "team-b", "tenant-x"})
+}
+
+// CloudMapRegistry registers the service in an AWS Cloud Map namespace
+type CloudMapRegistry struct {
+	Namespace   string
+	NamespaceID string
+}
+
+// Kind identifies this registry as the Cloud Map backend
+func (r CloudMapRegistry) Kind() string { return "cloud_map" }
+
+// defaultConsulCheckIntervalSeconds and defaultConsulDeregisterCriticalAfterSeconds
+// match the common Kratos/Consul sidecar defaults
+const (
+	defaultConsulCheckIntervalSeconds           = 10
+	defaultConsulDeregisterCriticalAfterSeconds = 70
+)
+
+// ConsulRegistry registers the service with a Consul-managed service mesh via a
+// consul-agent sidecar injected into the task definition
+type ConsulRegistry struct {
+	ServiceARN                     string
+	CheckIntervalSeconds           int
+	DeregisterCriticalAfterSeconds int
+}
+
+// Kind identifies this registry as the Consul backend
+func (r ConsulRegistry) Kind() string { return "consul" }
+
+// genConsulRegistry generates a Consul registry configuration, defaulting the check
+// interval to 10s and deregister-critical-after to 70s
+func genConsulRegistry(env, serviceName string) *rapid.Generator[ConsulRegistry] {
+	return rapid.Custom(func(t *rapid.T) ConsulRegistry {
+		reg := ConsulRegistry{
+			ServiceARN:                     fmt.Sprintf("arn:aws:servicediscovery:us-east-1:123456789012:service/consul-%s-%s", serviceName, env),
+			CheckIntervalSeconds:           defaultConsulCheckIntervalSeconds,
+			DeregisterCriticalAfterSeconds: defaultConsulDeregisterCriticalAfterSeconds,
+		}
+		if rapid.Bool().Draw(t, "override_consul_check_interval") {
+			reg.CheckIntervalSeconds = rapid.SampledFrom([]int{5, 15, 30}).Draw(t, "consul_check_interval_seconds")
+		}
+		if rapid.Bool().Draw(t, "override_consul_deregister_critical_after") {
+			reg.DeregisterCriticalAfterSeconds = rapid.SampledFrom([]int{30, 90, 120}).Draw(t, "consul_deregister_critical_after_seconds")
+		}
+		return reg
+	})
+}
+
+// healthCheckGracePeriodCeiling is the AWS-imposed upper bound on
+// health_check_grace_period_seconds, raised from the old 7200-second ceiling to
+// math.MaxInt32 to support multi-hour blue/green cutovers
+const healthCheckGracePeriodCeiling = math.MaxInt32
+
+// validateHealthCheckGracePeriodSeconds fails fast for values outside the range the
+// aws_ecs_service resource accepts
+func validateHealthCheckGracePeriodSeconds(seconds int) error {
+	if seconds < 0 || seconds > healthCheckGracePeriodCeiling {
+		return fmt.Errorf("health_check_grace_period_seconds must be in [0, %d], got %d", healthCheckGracePeriodCeiling, seconds)
+	}
+	return nil
+}
+
+// genHealthCheckGracePeriodSeconds generates a value across the full valid range,
+// including the old 7200s ceiling and the new math.MaxInt32 ceiling
+func genHealthCheckGracePeriodSeconds() *rapid.Generator[int] {
+	return rapid.Custom(func(t *rapid.T) int {
+		return rapid.SampledFrom([]int{0, 60, 300, 7200, 7201, healthCheckGracePeriodCeiling}).Draw(t, "health_check_grace_period_seconds")
+	})
+}
+
+// ALBHealthCheckConfig configures the health check of a module-owned target group
+type ALBHealthCheckConfig struct {
+	Path            string
+	Matcher         string
+	IntervalSeconds int
+	TimeoutSeconds  int
+}
+
+// genALBHealthCheckConfig generates a valid health check configuration
+func genALBHealthCheckConfig() *rapid.Generator[ALBHealthCheckConfig] {
+	return rapid.Custom(func(t *rapid.T) ALBHealthCheckConfig {
+		return ALBHealthCheckConfig{
+			Path:            rapid.SampledFrom([]string{"/", "/health", "/healthz", "/status"}).Draw(t, "health_check_path"),
+			Matcher:         rapid.SampledFrom([]string{"200", "200-299", "200,204"}).Draw(t, "health_check_matcher"),
+			IntervalSeconds: rapid.SampledFrom([]int{10, 15, 30}).Draw(t, "health_check_interval"),
+			TimeoutSeconds:  rapid.SampledFrom([]int{5, 10}).Draw(t, "health_check_timeout"),
+		}
+	})
+}
+
+// genHostHeaders generates listener rule host-header conditions
+func genHostHeaders(serviceName, env string) *rapid.Generator[[]string] {
+	return rapid.Just([]string{fmt.Sprintf("%s.%s.example.com", serviceName, env)})
+}
+
+// genPathPatterns generates listener rule path-pattern conditions
+func genPathPatterns() *rapid.Generator[[]string] {
+	return rapid.SampledFrom([][]string{
+		{"/*"},
+		{"/api/*"},
+		{"/api/*", "/health"},
+	})
+}
+
+// genPartition generates a valid AWS partition, including the GovCloud/ISO partitions
+// where tags-on-create may fall back to a separate tagging API call
+func genPartition() *rapid.Generator[string] {
+	return rapid.SampledFrom([]string{"aws", "aws-us-gov", "aws-iso", "aws-iso-b"})
+}
+
+// genTags generates a small set of resource tags
+func genTags() *rapid.Generator[map[string]string] {
+	return rapid.Custom(func(t *rapid.T) map[string]string {
+		count := rapid.IntRange(1, 3).Draw(t, "tag_count")
+		tags := make(map[string]string, count)
+		for i := 0; i < count; i++ {
+			key := fmt.Sprintf("tag-key-%d", i)
+			tags[key] = rapid.StringMatching(`[a-z0-9-]{3,12}`).Draw(t, fmt.Sprintf("tag_value_%d", i))
+		}
+		return tags
+	})
+}
+
+// genPropagateTags generates a valid propagate_tags setting
+func genPropagateTags() *rapid.Generator[string] {
+	return rapid.SampledFrom([]string{"SERVICE", "TASK_DEFINITION", "NONE"})
+}
+
+// AutoscalingConfig configures application auto scaling for an ECS service
+type AutoscalingConfig struct {
+	Enabled             bool
+	MinCapacity         int
+	MaxCapacity         int
+	TargetCPUPercent    float64
+	TargetMemoryPercent float64
+	ScaleInCooldown     int
+	ScaleOutCooldown    int
+}
+
+// genAutoscalingConfig generates a valid autoscaling configuration whose min/max
+// capacity bounds the given desired count
+func genAutoscalingConfig(desiredCount int) *rapid.Generator[AutoscalingConfig] {
+	return rapid.Custom(func(t *rapid.T) AutoscalingConfig {
+		minCapacity := rapid.IntRange(1, desiredCount).Draw(t, "autoscaling_min_capacity")
+		maxCapacity := rapid.IntRange(desiredCount, desiredCount+10).Draw(t, "autoscaling_max_capacity")
+
+		return AutoscalingConfig{
+			Enabled:             true,
+			MinCapacity:         minCapacity,
+			MaxCapacity:         maxCapacity,
+			TargetCPUPercent:    float64(rapid.SampledFrom([]int{50, 60, 70, 80}).Draw(t, "target_cpu_percent")),
+			TargetMemoryPercent: float64(rapid.SampledFrom([]int{50, 60, 70, 80}).Draw(t, "target_memory_percent")),
+			ScaleInCooldown:     rapid.SampledFrom([]int{60, 120, 300}).Draw(t, "scale_in_cooldown"),
+			ScaleOutCooldown:    rapid.SampledFrom([]int{60, 120, 300}).Draw(t, "scale_out_cooldown"),
+		}
+	})
+}
+
+// genPrivateSubnetIDs generates mock private subnet IDs
+func genPrivateSubnetIDs() *rapid.Generator[[]string] {
+	return rapid.SampledFrom([][]string{
+		{"subnet-private-1a", "subnet-private-1b"},
+		{"subnet-private-1a", "subnet-private-1b", "subnet-private-1c"},
+	})
+}
+
+// genSecurityGroupIDs generates mock security group IDs
+func genSecurityGroupIDs() *rapid.Generator[[]string] {
+	return rapid.Custom(func(t *rapid.T) []string {
+		count := rapid.IntRange(1, 3).Draw(t, "sg_count")
+		sgs := make([]string, count)
+		for i := 0; i < count; i++ {
+			sgs[i] = fmt.Sprintf("sg-%d", rapid.IntRange(100000, 999999).Draw(t, fmt.Sprintf("sg_%d", i)))
+		}
+		return sgs
+	})
+}
+
+// genECSServiceConfig generates a valid ECS service configuration
+func genECSServiceConfig() *rapid.Generator[ECSServiceConfig] {
+	return rapid.Custom(func(t *rapid.T) ECSServiceConfig {
+		serviceType := genServiceType().Draw(t, "service_type")
+		serviceName := genServiceName().Draw(t, "service_name")
+		env := genEnvironment().Draw(t, "environment")
+		projectName := genProjectName().Draw(t, "project_name")
+		partition := genPartition().Draw(t, "partition")
+
+		config := ECSServiceConfig{
+			Environment:                     env,
+			ProjectName:                     projectName,
+			ServiceName:                     serviceName,
+			ServiceType:                     serviceType,
+			ClusterARN:                      fmt.Sprintf("arn:%s:ecs:us-east-1:123456789012:cluster/%s-%s-cluster", partition, projectName, env),
+			ClusterName:                     fmt.Sprintf("%s-%s-cluster", projectName, env),
+			TaskDefinitionARN:               fmt.Sprintf("arn:%s:ecs:us-east-1:123456789012:task-definition/%s-%s:1", partition, serviceName, env),
+			Partition:                       partition,
+			Tags:                            genTags().Draw(t, "tags"),
+			PropagateTags:                   genPropagateTags().Draw(t, "propagate_tags"),
+			ContainerName:                   serviceName,
+			ContainerPort:                   genContainerPort().Draw(t, "container_port"),
+			DesiredCount:                    genDesiredCount().Draw(t, "desired_count"),
+			DeploymentMinimumHealthyPercent: genDeploymentMinHealthyPercent().Draw(t, "min_healthy"),
+			DeploymentMaximumPercent:        genDeploymentMaxPercent().Draw(t, "max_percent"),
+			PrivateSubnetIDs:                genPrivateSubnetIDs().Draw(t, "private_subnets"),
+			SecurityGroupIDs:                genSecurityGroupIDs().Draw(t, "security_groups"),
+		}
+
+		// Set service-type specific configurations
+		if serviceType == "public" {
+			config.TargetGroupARN = fmt.Sprintf("arn:%s:elasticloadbalancing:us-east-1:123456789012:targetgroup/%s-%s/1234567890", partition, serviceName, env)
+			config.EnableServiceDiscovery = false
+
+			// Either the caller supplies an existing target group ARN (above), or the
+			// module owns the target group + listener rule end-to-end
+			if rapid.Bool().Draw(t, "create_target_group") {
+				config.CreateTargetGroup = true
+				config.TargetGroupARN = ""
+				config.ListenerARN = fmt.Sprintf("arn:%s:elasticloadbalancing:us-east-1:123456789012:listener/app/%s-%s/1234567890/abcdef1234567890", partition, projectName, env)
+				config.HostHeaders = genHostHeaders(serviceName, env).Draw(t, "host_headers")
+				config.PathPatterns = genPathPatterns().Draw(t, "path_patterns")
+				config.HealthCheck = genALBHealthCheckConfig().Draw(t, "health_check")
+			}
+		} else {
+			config.TargetGroupARN = ""
+			discoveryMode := rapid.SampledFrom([]string{"none", "cloud_map", "consul", "service_connect"}).Draw(t, "discovery_mode")
+			switch discoveryMode {
+			case "cloud_map":
+				config.EnableServiceDiscovery = true
+				config.Namespace = genNamespace().Draw(t, "namespace")
+				config.ServiceDiscoveryNamespaceID = fmt.Sprintf("ns-%s-%s", config.Namespace, env)
+				config.ServiceRegistries = []ServiceRegistry{CloudMapRegistry{Namespace: config.Namespace, NamespaceID: config.ServiceDiscoveryNamespaceID}}
+			case "consul":
+				config.EnableServiceDiscovery = true
+				config.ServiceRegistries = []ServiceRegistry{genConsulRegistry(env, serviceName).Draw(t, "consul_registry")}
+			case "service_connect":
+				config.ServiceConnect = genServiceConnectConfig(env, serviceName, config.ContainerPort).Draw(t, "service_connect")
+			}
+		}
+
+		// Deployment controller / circuit breaker: the circuit breaker is only
+		// valid alongside the ECS controller, so keep the generator itself honest
+		// rather than exercising the invalid combination here
+		config.DeploymentController = genDeploymentController().Draw(t, "deployment_controller")
+		if config.DeploymentController.Type == "ECS" && rapid.Bool().Draw(t, "enable_circuit_breaker") {
+			breaker := genDeploymentCircuitBreaker().Draw(t, "circuit_breaker")
+			config.DeploymentCircuitBreaker = &breaker
+		}
+
+		// Capacity provider strategy is mutually exclusive with the module's
+		// default launch_type = "FARGATE" behavior
+		if rapid.Bool().Draw(t, "use_capacity_provider_strategy") {
+			config.CapacityProviderStrategy = genCapacityProviderStrategy().Draw(t, "capacity_provider_strategy")
+		}
+
+		if rapid.Bool().Draw(t, "enable_autoscaling") {
+			config.Autoscaling = genAutoscalingConfig(config.DesiredCount).Draw(t, "autoscaling")
+		}
+
+		if rapid.Bool().Draw(t, "override_health_check_grace_period") {
+			grace := genHealthCheckGracePeriodSeconds().Draw(t, "health_check_grace_period_seconds")
+			config.HealthCheckGracePeriodSeconds = &grace
+		}
+
+		if config.Namespace == "" {
+			config.Namespace = DefaultNamespace
+		}
+
+		return config
+	})
+}
+
+// toTerraformVars converts ECSServiceConfig to Terraform variables map
+func (c ECSServiceConfig) toTerraformVars() map[string]interface{} {
+	vars := map[string]interface{}{
+		"environment":                        c.Environment,
+		"project_name":                       c.ProjectName,
+		"service_name":                       c.ServiceName,
+		"service_type":                       c.ServiceType,
+		"cluster_arn":                        c.ClusterARN,
+		"cluster_name":                       c.ClusterName,
+		"task_definition_arn":                c.TaskDefinitionARN,
+		"container_name":                     c.ContainerName,
+		"container_port":                     c.ContainerPort,
+		"desired_count":                      c.DesiredCount,
+		"deployment_minimum_healthy_percent": c.DeploymentMinimumHealthyPercent,
+		"deployment_maximum_percent":         c.DeploymentMaximumPercent,
+		"private_subnet_ids":                 c.PrivateSubnetIDs,
+		"security_group_ids":                 c.SecurityGroupIDs,
+		"enable_service_discovery":           c.EnableServiceDiscovery,
+	}
+
+	if c.ServiceType == "public" && c.TargetGroupARN != "" {
+		vars["target_group_arn"] = c.TargetGroupARN
+	}
+
+	if c.EnableServiceDiscovery && c.ServiceDiscoveryNamespaceID != "" {
+		vars["service_discovery_namespace_id"] = c.ServiceDiscoveryNamespaceID
+	}
+
+	if c.ServiceConnect.Enabled {
+		vars["service_connect_configuration"] = c.ServiceConnect
+	}
+
+	vars["deployment_controller_type"] = c.DeploymentController.Type
+	if c.DeploymentCircuitBreaker != nil {
+		vars["deployment_circuit_breaker"] = *c.DeploymentCircuitBreaker
+	}
+
+	if len(c.CapacityProviderStrategy) > 0 {
+		vars["capacity_provider_strategy"] = c.CapacityProviderStrategy
+	}
+
+	vars["enable_autoscaling"] = c.Autoscaling.Enabled
+	if c.Autoscaling.Enabled {
+		vars["autoscaling_min_capacity"] = c.Autoscaling.MinCapacity
+		vars["autoscaling_max_capacity"] = c.Autoscaling.MaxCapacity
+		vars["autoscaling_target_cpu_percent"] = c.Autoscaling.TargetCPUPercent
+		vars["autoscaling_target_memory_percent"] = c.Autoscaling.TargetMemoryPercent
+	}
+
+	vars["tags"] = c.Tags
+	vars["propagate_tags"] = c.PropagateTags
+
+	vars["create_target_group"] = c.CreateTargetGroup
+	if c.CreateTargetGroup {
+		vars["listener_arn"] = c.ListenerARN
+		vars["host_headers"] = c.HostHeaders
+		vars["path_patterns"] = c.PathPatterns
+		vars["health_check"] = c.HealthCheck
+	}
+
+	if c.HealthCheckGracePeriodSeconds != nil {
+		vars["health_check_grace_period_seconds"] = *c.HealthCheckGracePeriodSeconds
+	}
+
+	for _, registry := range c.ServiceRegistries {
+		if consul, ok := registry.(ConsulRegistry); ok {
+			vars["service_registry_type"] = "consul"
+			vars["consul_service_arn"] = consul.ServiceARN
+			vars["consul_check_interval_seconds"] = consul.CheckIntervalSeconds
+			vars["consul_deregister_critical_after_seconds"] = consul.DeregisterCriticalAfterSeconds
+		}
+	}
+
+	vars["namespace"] = c.Namespace
+
+	return vars
+}
+
+// ECSServicePlanOutput represents the JSON output of terraform show -json for ECS service
+type ECSServicePlanOutput struct {
+	PlannedValues struct {
+		RootModule struct {
+			Resources []struct {
+				Address string                 `json:"address"`
+				Type    string                 `json:"type"`
+				Name    string                 `json:"name"`
+				Values  map[string]interface{} `json:"values"`
+			} `json:"resources"`
+		} `json:"root_module"`
+	} `json:"planned_values"`
+	Configuration struct {
+		RootModule struct {
+			ModuleCalls map[string]struct {
+				Module struct {
+					Resources []configResource `json:"resources"`
+				} `json:"module"`
+			} `json:"module_calls"`
+		} `json:"root_module"`
+	} `json:"configuration"`
+}
+
+// configExpression is the `references` Terraform's configuration JSON attaches to an
+// attribute's expression when its value comes from another resource, e.g.
+// `target_group_arn = aws_lb_target_group.this.arn` references "aws_lb_target_group.this.arn".
+type configExpression struct {
+	References []string `json:"references"`
+}
+
+// configResource is one entry in configuration.root_module.module_calls[...].module.resources:
+// the config-time facts (explicit depends_on, attribute expression references) that are known
+// on a plan that hasn't been applied, unlike the resource's own (possibly still-unknown) values.
+type configResource struct {
+	Address     string                     `json:"address"`
+	DependsOn   []string                   `json:"depends_on"`
+	Expressions map[string]json.RawMessage `json:"expressions"`
+}
+
+// actionTargetGroupReferences returns the resource references recorded for the first action
+// block's target_group_arn expression, so a listener rule's wiring can be checked against what
+// it references in config rather than its plan-time-unknown ARN.
+func (r configResource) actionTargetGroupReferences() []string {
+	raw, ok := r.Expressions["action"]
+	if !ok {
+		return nil
+	}
+	var blocks []map[string]configExpression
+	if err := json.Unmarshal(raw, &blocks); err != nil || len(blocks) == 0 {
+		return nil
+	}
+	return blocks[0]["target_group_arn"].References
+}
+
+// getResourcesByType returns all resources of a given type from the plan
+func (p *ECSServicePlanOutput) getResourcesByType(resourceType string) []map[string]interface{} {
+	var resources []map[string]interface{}
+	for _, r := range p.PlannedValues.RootModule.Resources {
+		if r.Type == resourceType {
+			resources = append(resources, r.Values)
+		}
+	}
+	return resources
+}
+
+// getResourcesWithAddressByType is like getResourcesByType but retains each resource's
+// address, needed to correlate resources via their config rather than a computed attribute
+func (p *ECSServicePlanOutput) getResourcesWithAddressByType(resourceType string) []plannedResourceWithAddress {
+	var resources []plannedResourceWithAddress
+	for _, r := range p.PlannedValues.RootModule.Resources {
+		if r.Type == resourceType {
+			resources = append(resources, plannedResourceWithAddress{Address: r.Address, Values: r.Values})
+		}
+	}
+	return resources
+}
+
+// configResourceByAddress finds a resource's configuration entry (depends_on + expressions) by
+// its address relative to the ecs_service module call, e.g. "aws_lb_target_group.this"
+func (p *ECSServicePlanOutput) configResourceByAddress(relativeAddress string) (configResource, bool) {
+	for _, call := range p.Configuration.RootModule.ModuleCalls {
+		for _, r := range call.Module.Resources {
+			if r.Address == relativeAddress {
+				return r, true
+			}
+		}
+	}
+	return configResource{}, false
+}
+
+// moduleRelativeAddress strips the wrapper harness's "module.ecs_service." prefix from a
+// planned resource's full address, matching the relative addressing the configuration block
+// uses for resources declared inside the called module.
+func moduleRelativeAddress(address string) string {
+	return strings.TrimPrefix(address, "module.ecs_service.")
+}
+
+// getECSServiceModulePath returns the absolute path to the ecs-service module
+func getECSServiceModulePath(t *testing.T) string {
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	modulePath := filepath.Join(cwd, "..", "..", "terraform", "modules", "ecs-service")
+	_, err = os.Stat(modulePath)
+	require.NoError(t, err, "Module path does not exist: %s", modulePath)
+	return modulePath
+}
+
+// runECSServiceTerraformPlan runs terraform plan and returns the JSON output
+func runECSServiceTerraformPlan(t *testing.T, modulePath string, config ECSServiceConfig, planName string) *ECSServicePlanOutput {
+	// Create a temporary directory for the test
+	tempDir, err := os.MkdirTemp("", "terraform-test-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	// Create a test configuration file that uses the module
+	tfConfig := fmt.Sprintf(`
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = "us-east-1"
+  skip_credentials_validation = true
+  skip_metadata_api_check     = true
+  skip_requesting_account_id  = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+module "ecs_service" {
+  source = "%s"
+
+  environment   = "%s"
+  project_name  = "%s"
+  service_name  = "%s"
+  service_type  = "%s"
+
+  cluster_arn         = "%s"
+  cluster_name        = "%s"
+  task_definition_arn = "%s"
+  container_name      = "%s"
+  container_port      = %d
+
+  desired_count                      = %d
+  deployment_minimum_healthy_percent = %d
+  deployment_maximum_percent         = %d
+
+  private_subnet_ids = %s
+  security_group_ids = %s
+
+  target_group_arn = %s
+
+  enable_service_discovery       = %t
+  service_discovery_namespace_id = %s
+%s
+
+  deployment_controller_type = "%s"
+%s
+%s
+%s
+
+  tags            = %s
+  propagate_tags  = "%s"
+%s
+
+  health_check_grace_period_seconds = %s
+%s
+
+  namespace = "%s"
+}
+`,
+		modulePath,
+		config.Environment,
+		config.ProjectName,
+		config.ServiceName,
+		config.ServiceType,
+		config.ClusterARN,
+		config.ClusterName,
+		config.TaskDefinitionARN,
+		config.ContainerName,
+		config.ContainerPort,
+		config.DesiredCount,
+		config.DeploymentMinimumHealthyPercent,
+		config.DeploymentMaximumPercent,
+		toHCLList(config.PrivateSubnetIDs),
+		toHCLList(config.SecurityGroupIDs),
+		toHCLString(config.TargetGroupARN),
+		config.EnableServiceDiscovery,
+		toHCLString(config.ServiceDiscoveryNamespaceID),
+		toHCLServiceConnectBlock(config.ServiceConnect),
+		config.DeploymentController.Type,
+		toHCLDeploymentCircuitBreakerBlock(config.DeploymentCircuitBreaker),
+		toHCLCapacityProviderStrategyBlock(config.CapacityProviderStrategy),
+		toHCLAutoscalingBlock(config.Autoscaling),
+		toHCLMap(config.Tags),
+		config.PropagateTags,
+		toHCLALBCoResourceBlock(config),
+		toHCLIntPointer(config.HealthCheckGracePeriodSeconds),
+		toHCLServiceRegistriesBlock(config.ServiceRegistries),
+		config.Namespace,
+	)
+
+	// Write the test configuration
+	testConfigPath := filepath.Join(tempDir, "main.tf")
+	err = os.WriteFile(testConfigPath, []byte(tfConfig), 0644)
+	require.NoError(t, err)
+
+	// Initialize Terraform
+	initCmd := exec.Command("terraform", "init")
+	initCmd.Dir = tempDir
+	initOutput, err := initCmd.CombinedOutput()
+	require.NoError(t, err, "Failed to init terraform: %s", string(initOutput))
+
+	// Create plan
+	planFilePath := filepath.Join(tempDir, "plan.tfplan")
+	planCmd := exec.Command("terraform", "plan", "-out="+planFilePath, "-input=false")
+	planCmd.Dir = tempDir
+	planOutput, err := planCmd.CombinedOutput()
+	require.NoError(t, err, "Failed to create terraform plan: %s", string(planOutput))
+
+	// Get JSON output
+	showCmd := exec.Command("terraform", "show", "-json", planFilePath)
+	showCmd.Dir = tempDir
+	jsonOutput, err := showCmd.CombinedOutput()
+	require.NoError(t, err, "Failed to show terraform plan: %s", string(jsonOutput))
+
+	// Parse JSON
+	var plan ECSServicePlanOutput
+	err = json.Unmarshal(jsonOutput, &plan)
+	require.NoError(t, err, "Failed to parse terraform plan JSON")
+
+	return &plan
+}
+
+// toHCLList converts a string slice to HCL list format
+func toHCLList(items []string) string {
+	if len(items) == 0 {
+		return "[]"
+	}
+	result := "["
+	for i, item := range items {
+		if i > 0 {
+			result += ", "
+		}
+		result += fmt.Sprintf(`"%s"`, item)
+	}
+	result += "]"
+	return result
+}
+
+// toHCLString converts a string to HCL string format (handles null)
+func toHCLString(s string) string {
+	if s == "" {
+		return "null"
+	}
+	return fmt.Sprintf(`"%s"`, s)
+}
+
+// toHCLServiceConnectBlock renders the service_connect_configuration block for the
+// module call, or an empty string when Service Connect is not enabled for this service
+func toHCLServiceConnectBlock(c ServiceConnectConfig) string {
+	if !c.Enabled {
+		return ""
+	}
+
+	var services strings.Builder
+	for _, svc := range c.Services {
+		services.WriteString(fmt.Sprintf(`    {
+      port_name      = "%s"
+      discovery_name = %s
+`, svc.PortName, toHCLString(svc.DiscoveryName)))
+		if c.Mode == "client-server" {
+			services.WriteString(fmt.Sprintf(`      client_alias = {
+        port     = %d
+        dns_name = "%s"
+      }
+`, svc.ClientAliasPort, svc.ClientAliasDNSName))
+		}
+		services.WriteString("    },\n")
+	}
+
+	return fmt.Sprintf(`
+  service_connect_configuration = {
+    enabled    = true
+    namespace  = "%s"
+    services   = [
+%s    ]
+  }`, c.NamespaceARN, services.String())
+}
+
+// toHCLServiceRegistriesBlock renders the Consul-specific registry inputs, or an
+// empty string when the service uses Cloud Map (or no registry) instead
+func toHCLServiceRegistriesBlock(registries []ServiceRegistry) string {
+	for _, registry := range registries {
+		if consul, ok := registry.(ConsulRegistry); ok {
+			return fmt.Sprintf(`
+  service_registry_type                   = "consul"
+  consul_service_arn                      = "%s"
+  consul_check_interval_seconds           = %d
+  consul_deregister_critical_after_seconds = %d`,
+				consul.ServiceARN, consul.CheckIntervalSeconds, consul.DeregisterCriticalAfterSeconds)
+		}
+	}
+	return ""
+}
+
+// toHCLIntPointer converts an optional int to HCL, rendering null when unset
+func toHCLIntPointer(v *int) string {
+	if v == nil {
+		return "null"
+	}
+	return fmt.Sprintf("%d", *v)
+}
+
+// toHCLMap converts a string map to HCL map format
+func toHCLMap(m map[string]string) string {
+	if len(m) == 0 {
+		return "{}"
+	}
+	var b strings.Builder
+	b.WriteString("{\n")
+	for k, v := range m {
+		b.WriteString(fmt.Sprintf("    %q = %q\n", k, v))
+	}
+	b.WriteString("  }")
+	return b.String()
+}
+
+// toHCLALBCoResourceBlock renders the create_target_group inputs, or just the
+// disabled flag when the service references an externally-managed target group
+func toHCLALBCoResourceBlock(c ECSServiceConfig) string {
+	if !c.CreateTargetGroup {
+		return "\n  create_target_group = false"
+	}
+
+	return fmt.Sprintf(`
+  create_target_group = true
+  listener_arn         = "%s"
+  host_headers         = %s
+  path_patterns        = %s
+  health_check = {
+    path     = "%s"
+    matcher  = "%s"
+    interval = %d
+    timeout  = %d
+  }`,
+		c.ListenerARN,
+		toHCLList(c.HostHeaders),
+		toHCLList(c.PathPatterns),
+		c.HealthCheck.Path,
+		c.HealthCheck.Matcher,
+		c.HealthCheck.IntervalSeconds,
+		c.HealthCheck.TimeoutSeconds,
+	)
+}
+
+// toHCLDeploymentCircuitBreakerBlock renders the deployment_circuit_breaker block,
+// or an empty string when no circuit breaker is configured for this service
+func toHCLDeploymentCircuitBreakerBlock(b *DeploymentCircuitBreaker) string {
+	if b == nil {
+		return ""
+	}
+	return fmt.Sprintf(`
+  deployment_circuit_breaker = {
+    enable   = %t
+    rollback = %t
+  }`, b.Enable, b.Rollback)
+}
+
+// toHCLCapacityProviderStrategyBlock renders the capacity_provider_strategy block,
+// or an empty string to leave the module on its default launch_type = "FARGATE"
+func toHCLCapacityProviderStrategyBlock(entries []CapacityProviderStrategyEntry) string {
+	if len(entries) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\n  capacity_provider_strategy = [\n")
+	for _, e := range entries {
+		b.WriteString(fmt.Sprintf(`    {
+      capacity_provider = "%s"
+      weight            = %d
+      base              = %d
+    },
+`, e.Provider, e.Weight, e.Base))
+	}
+	b.WriteString("  ]")
+	return b.String()
+}
+
+// toHCLAutoscalingBlock renders the autoscaling-related module inputs, or the
+// disabled flag alone when autoscaling is not configured for this service
+func toHCLAutoscalingBlock(a AutoscalingConfig) string {
+	if !a.Enabled {
+		return "\n  enable_autoscaling = false"
+	}
+
+	return fmt.Sprintf(`
+  enable_autoscaling                = true
+  autoscaling_min_capacity          = %d
+  autoscaling_max_capacity          = %d
+  autoscaling_target_cpu_percent    = %g
+  autoscaling_target_memory_percent = %g
+  autoscaling_scale_in_cooldown     = %d
+  autoscaling_scale_out_cooldown    = %d`,
+		a.MinCapacity, a.MaxCapacity, a.TargetCPUPercent, a.TargetMemoryPercent, a.ScaleInCooldown, a.ScaleOutCooldown)
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 21: ECS service per microservice
+// *For any* service configuration, exactly one ECS service should be created with a unique name
+// **Validates: Requirements 5.1**
+func TestProperty21_ECSServicePerMicroservice(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		config := genECSServiceConfig().Draw(rt, "config")
+
+		modulePath := getECSServiceModulePath(t)
+		planName := fmt.Sprintf("plan-p21-%s-%s", config.ServiceName, config.Environment)
+		plan := runECSServiceTerraformPlan(t, modulePath, config, planName)
+
+		// Property 21.1: Exactly one ECS service should be created
+		ecsServices := plan.getResourcesByType("aws_ecs_service")
+		assert.Len(t, ecsServices, 1, "Exactly one ECS service should be created per module invocation")
+
+		if len(ecsServices) > 0 {
+			service := ecsServices[0]
+
+			// Property 21.2: Service name should be unique and include service name and environment
+			serviceName, ok := service["name"].(string)
+			assert.True(t, ok, "Service should have a name")
+			assert.Contains(t, serviceName, config.ServiceName, "Service name should contain the service name")
+			assert.Contains(t, serviceName, config.Environment, "Service name should contain the environment")
+
+			// Property 21.3: Service should be associated with the correct cluster
+			clusterARN, ok := service["cluster"].(string)
+			assert.True(t, ok, "Service should have a cluster")
+			assert.Equal(t, config.ClusterARN, clusterARN, "Service should be in the correct cluster")
+		}
+	})
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 24: Desired count configuration
+// *For any* ECS service configuration, desired_count should be set to a positive integer
+// **Validates: Requirements 5.4**
+func TestProperty24_DesiredCountConfiguration(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		config := genECSServiceConfig().Draw(rt, "config")
+
+		modulePath := getECSServiceModulePath(t)
+		planName := fmt.Sprintf("plan-p24-%s-%s", config.ServiceName, config.Environment)
+		plan := runECSServiceTerraformPlan(t, modulePath, config, planName)
+
+		// Property 24.1: ECS service should have desired_count set
+		ecsServices := plan.getResourcesByType("aws_ecs_service")
+		require.Len(t, ecsServices, 1, "Exactly one ECS service should be created")
+
+		service := ecsServices[0]
+
+		// Property 24.2: Desired count should match the input configuration
+		desiredCount, ok := service["desired_count"].(float64)
+		assert.True(t, ok, "Service should have desired_count set")
+		assert.Equal(t, float64(config.DesiredCount), desiredCount, "Desired count should match input")
+
+		// Property 24.3: Desired count should be a positive integer
+		assert.GreaterOrEqual(t, desiredCount, float64(1), "Desired count should be at least 1")
+	})
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 25: Rolling update configuration
+// *For any* ECS service deployment configuration, both minimum_healthy_percent and maximum_percent should be defined with valid values
+// **Validates: Requirements 5.5**
+func TestProperty25_RollingUpdateConfiguration(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		config := genECSServiceConfig().Draw(rt, "config")
+
+		modulePath := getECSServiceModulePath(t)
+		planName := fmt.Sprintf("plan-p25-%s-%s", config.ServiceName, config.Environment)
+		plan := runECSServiceTerraformPlan(t, modulePath, config, planName)
+
+		// Property 25.1: ECS service should have deployment configuration
+		ecsServices := plan.getResourcesByType("aws_ecs_service")
+		require.Len(t, ecsServices, 1, "Exactly one ECS service should be created")
+
+		service := ecsServices[0]
+
+		// Property 25.2: Deployment configuration should be present
+		deploymentConfig, ok := service["deployment_configuration"].([]interface{})
+		assert.True(t, ok && len(deploymentConfig) > 0, "Service should have deployment_configuration")
+
+		if len(deploymentConfig) > 0 {
+			config := deploymentConfig[0].(map[string]interface{})
+
+			// Property 25.3: minimum_healthy_percent should be defined
+			minHealthy, hasMinHealthy := config["minimum_healthy_percent"]
+			assert.True(t, hasMinHealthy, "Deployment configuration should have minimum_healthy_percent")
+			if hasMinHealthy {
+				minHealthyVal, ok := minHealthy.(float64)
+				assert.True(t, ok, "minimum_healthy_percent should be a number")
+				assert.GreaterOrEqual(t, minHealthyVal, float64(0), "minimum_healthy_percent should be >= 0")
+				assert.LessOrEqual(t, minHealthyVal, float64(200), "minimum_healthy_percent should be <= 200")
+			}
+
+			// Property 25.4: maximum_percent should be defined
+			maxPercent, hasMaxPercent := config["maximum_percent"]
+			assert.True(t, hasMaxPercent, "Deployment configuration should have maximum_percent")
+			if hasMaxPercent {
+				maxPercentVal, ok := maxPercent.(float64)
+				assert.True(t, ok, "maximum_percent should be a number")
+				assert.GreaterOrEqual(t, maxPercentVal, float64(100), "maximum_percent should be >= 100")
+				assert.LessOrEqual(t, maxPercentVal, float64(400), "maximum_percent should be <= 400")
+			}
+		}
+	})
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 27: Private subnet placement
+// *For any* ECS service network configuration, all subnet IDs should reference private subnets (not public subnets)
+// **Validates: Requirements 5.7**
+func TestProperty27_PrivateSubnetPlacement(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		config := genECSServiceConfig().Draw(rt, "config")
+
+		modulePath := getECSServiceModulePath(t)
+		planName := fmt.Sprintf("plan-p27-%s-%s", config.ServiceName, config.Environment)
+		plan := runECSServiceTerraformPlan(t, modulePath, config, planName)
+
+		// Property 27.1: ECS service should have network configuration
+		ecsServices := plan.getResourcesByType("aws_ecs_service")
+		require.Len(t, ecsServices, 1, "Exactly one ECS service should be created")
+
+		service := ecsServices[0]
+
+		// Property 27.2: Network configuration should be present
+		networkConfig, ok := service["network_configuration"].([]interface{})
+		assert.True(t, ok && len(networkConfig) > 0, "Service should have network_configuration")
+
+		if len(networkConfig) > 0 {
+			netConfig := networkConfig[0].(map[string]interface{})
+
+			// Property 27.3: Subnets should be configured
+			subnets, hasSubnets := netConfig["subnets"]
+			assert.True(t, hasSubnets, "Network configuration should have subnets")
+
+			if hasSubnets {
+				subnetList, ok := subnets.([]interface{})
+				assert.True(t, ok, "Subnets should be a list")
+				assert.GreaterOrEqual(t, len(subnetList), 1, "At least one subnet should be configured")
+
+				// Property 27.4: Subnets should match the private subnet IDs provided
+				for i, subnet := range subnetList {
+					subnetID, ok := subnet.(string)
+					assert.True(t, ok, "Subnet ID should be a string")
+					assert.Equal(t, config.PrivateSubnetIDs[i], subnetID, "Subnet should match input private subnet")
+				}
+			}
+
+			// Property 27.5: assign_public_ip should be false for private subnets
+			assignPublicIP, hasAssignPublicIP := netConfig["assign_public_ip"]
+			if hasAssignPublicIP {
+				assignPublicIPVal, ok := assignPublicIP.(bool)
+				assert.True(t, ok, "assign_public_ip should be a boolean")
+				assert.False(t, assignPublicIPVal, "assign_public_ip should be false for private subnet placement")
+			}
+		}
+	})
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 37: Target group attachment for public services
+// *For any* public-facing service, the ECS service should have a load_balancer block referencing a target group ARN
+// **Validates: Requirements 8.1**
+func TestProperty37_TargetGroupAttachmentForPublicServices(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		config := genECSServiceConfig().Draw(rt, "config")
+
+		// Only test public services
+		if config.ServiceType != "public" {
+			return
+		}
+
+		modulePath := getECSServiceModulePath(t)
+		planName := fmt.Sprintf("plan-p37-%s-%s", config.ServiceName, config.Environment)
+		plan := runECSServiceTerraformPlan(t, modulePath, config, planName)
+
+		// Property 37.1: ECS service should exist
+		ecsServices := plan.getResourcesByType("aws_ecs_service")
+		require.Len(t, ecsServices, 1, "Exactly one ECS service should be created")
+
+		service := ecsServices[0]
+
+		// Property 37.2: Public service should have load_balancer configuration
+		loadBalancer, ok := service["load_balancer"].([]interface{})
+		assert.True(t, ok && len(loadBalancer) > 0, "Public service should have load_balancer configuration")
+
+		if len(loadBalancer) > 0 {
+			lbConfig := loadBalancer[0].(map[string]interface{})
+
+			// Property 37.3: Load balancer should reference the target group ARN
+			targetGroupARN, hasTargetGroup := lbConfig["target_group_arn"]
+			assert.True(t, hasTargetGroup, "Load balancer should have target_group_arn")
+			if hasTargetGroup {
+				tgARN, ok := targetGroupARN.(string)
+				assert.True(t, ok, "target_group_arn should be a string")
+				assert.Equal(t, config.TargetGroupARN, tgARN, "Target group ARN should match input")
+			}
+
+			// Property 37.4: Load balancer should reference the correct container
+			containerName, hasContainerName := lbConfig["container_name"]
+			assert.True(t, hasContainerName, "Load balancer should have container_name")
+			if hasContainerName {
+				cName, ok := containerName.(string)
+				assert.True(t, ok, "container_name should be a string")
+				assert.Equal(t, config.ContainerName, cName, "Container name should match input")
+			}
+
+			// Property 37.5: Load balancer should reference the correct container port
+			containerPort, hasContainerPort := lbConfig["container_port"]
+			assert.True(t, hasContainerPort, "Load balancer should have container_port")
+			if hasContainerPort {
+				cPort, ok := containerPort.(float64)
+				assert.True(t, ok, "container_port should be a number")
+				assert.Equal(t, float64(config.ContainerPort), cPort, "Container port should match input")
+			}
+		}
+
+		// Property 37.6: Public service should have health_check_grace_period_seconds set,
+		// accepting the full [0, math.MaxInt32] range raised from the old 7200s ceiling
+		healthCheckGrace, hasHealthCheckGrace := service["health_check_grace_period_seconds"]
+		assert.True(t, hasHealthCheckGrace, "Public service should have health_check_grace_period_seconds")
+		if hasHealthCheckGrace {
+			graceVal, ok := healthCheckGrace.(float64)
+			assert.True(t, ok, "health_check_grace_period_seconds should be a number")
+			assert.GreaterOrEqual(t, graceVal, float64(0), "health_check_grace_period_seconds should be >= 0")
+			assert.LessOrEqual(t, graceVal, float64(healthCheckGracePeriodCeiling), "health_check_grace_period_seconds should be <= math.MaxInt32")
+
+			if config.HealthCheckGracePeriodSeconds != nil {
+				assert.Equal(t, float64(*config.HealthCheckGracePeriodSeconds), graceVal, "Explicit health_check_grace_period_seconds override should round-trip unchanged")
+			}
+		}
+	})
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 42: No ALB for internal services
+// *For any* internal service configuration, the ECS service should not have a load_balancer block
+// **Validates: Requirements 8.7**
+func TestProperty42_NoALBForInternalServices(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		config := genECSServiceConfig().Draw(rt, "config")
+
+		// Only test internal services
+		if config.ServiceType != "internal" {
+			return
+		}
+
+		modulePath := getECSServiceModulePath(t)
+		planName := fmt.Sprintf("plan-p42-%s-%s", config.ServiceName, config.Environment)
+		plan := runECSServiceTerraformPlan(t, modulePath, config, planName)
+
+		// Property 42.1: ECS service should exist
+		ecsServices := plan.getResourcesByType("aws_ecs_service")
+		require.Len(t, ecsServices, 1, "Exactly one ECS service should be created")
+
+		service := ecsServices[0]
+
+		// Property 42.2: Internal service should NOT have load_balancer configuration
+		loadBalancer, ok := service["load_balancer"].([]interface{})
+		if ok {
+			assert.Len(t, loadBalancer, 0, "Internal service should not have load_balancer configuration")
+		}
+
+		// Property 42.3: Internal service should NOT have health_check_grace_period_seconds
+		// (only services with load balancers need this) -- this holds even when an explicit
+		// override was requested, since internal services have no load balancer to grace-period
+		healthCheckGrace, hasHealthCheckGrace := service["health_check_grace_period_seconds"]
+		if hasHealthCheckGrace {
+			// If present, it should be null or 0 for internal services
+			if healthCheckGrace != nil {
+				graceVal, ok := healthCheckGrace.(float64)
+				if ok {
+					assert.Equal(t, float64(0), graceVal, "Internal service should not have health_check_grace_period_seconds set")
+				}
+			}
+		}
+
+		// Property 42.4: If service discovery is enabled, service_registries should be
+		// present regardless of which registry backend (Cloud Map or Consul) is used
+		if config.EnableServiceDiscovery || len(config.ServiceRegistries) > 0 {
+			serviceRegistries, ok := service["service_registries"].([]interface{})
+			assert.True(t, ok && len(serviceRegistries) > 0, "Internal service with service discovery should have service_registries")
+		}
+	})
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 44: Service Connect configuration
+// *For any* internal service configuration with Service Connect enabled, the planned
+// aws_ecs_service should carry exactly one service_connect_configuration block that
+// propagates the namespace verbatim, maps each service's port_name back to a declared
+// task definition port mapping, defaults discovery_name to the container name when
+// omitted, and (in client-server mode) exposes a client_alias at the container port.
+// Service Connect is mutually exclusive with a target group, since it replaces the
+// ALB/Cloud Map service-registry path with namespace-based discovery.
+// **Validates: Requirements 5.1, 8.7**
+func TestProperty44_ServiceConnectConfiguration(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		config := genECSServiceConfig().Draw(rt, "config")
+
+		// Only test services where Service Connect was selected as the discovery mode
+		if !config.ServiceConnect.Enabled {
+			return
+		}
+
+		modulePath := getECSServiceModulePath(t)
+		planName := fmt.Sprintf("plan-p44-%s-%s", config.ServiceName, config.Environment)
+		plan := runECSServiceTerraformPlan(t, modulePath, config, planName)
+
+		// Property 44.1: ECS service should exist
+		ecsServices := plan.getResourcesByType("aws_ecs_service")
+		require.Len(t, ecsServices, 1, "Exactly one ECS service should be created")
+
+		service := ecsServices[0]
+
+		// Property 44.2: Service Connect services should not carry a load_balancer block —
+		// service discovery happens through the Service Connect proxy, not an ALB target group
+		_, hasTargetGroup := service["load_balancer"]
+		assert.False(t, hasTargetGroup, "Service Connect services should not have a load_balancer block")
+
+		// Property 44.3: Exactly one service_connect_configuration block, namespace propagated verbatim
+		scConfig, ok := service["service_connect_configuration"].([]interface{})
+		assert.True(t, ok && len(scConfig) == 1, "Service should have exactly one service_connect_configuration block")
+
+		if ok && len(scConfig) == 1 {
+			sc := scConfig[0].(map[string]interface{})
+
+			namespace, hasNamespace := sc["namespace"]
+			assert.True(t, hasNamespace, "service_connect_configuration should have a namespace")
+			assert.Equal(t, config.ServiceConnect.NamespaceARN, namespace, "Namespace should be propagated verbatim")
+
+			svcEntries, ok := sc["service"].([]interface{})
+			assert.True(t, ok && len(svcEntries) == len(config.ServiceConnect.Services), "Each configured Service Connect service should appear in the plan")
+
+			for i, entry := range svcEntries {
+				svc := entry.(map[string]interface{})
+				expected := config.ServiceConnect.Services[i]
+
+				// Property 44.4: port_name should match a declared task definition port mapping
+				portName, ok := svc["port_name"].(string)
+				assert.True(t, ok, "Service Connect entry should have a port_name")
+				assert.Equal(t, expected.PortName, portName, "port_name should match the declared port mapping")
+
+				// Property 44.5: discovery_name defaults to the container name when omitted
+				discoveryName, _ := svc["discovery_name"].(string)
+				if expected.DiscoveryName == "" {
+					assert.Equal(t, config.ContainerName, discoveryName, "discovery_name should default to the container name when omitted")
+				} else {
+					assert.Equal(t, expected.DiscoveryName, discoveryName, "discovery_name should match the explicit override")
+				}
+
+				// Property 44.6: client_alias port equals the configured container port (client-server mode only)
+				if config.ServiceConnect.Mode == "client-server" {
+					clientAlias, ok := svc["client_alias"].([]interface{})
+					assert.True(t, ok && len(clientAlias) > 0, "client-server mode should expose a client_alias")
+					if ok && len(clientAlias) > 0 {
+						alias := clientAlias[0].(map[string]interface{})
+						aliasPort, ok := alias["port"].(float64)
+						assert.True(t, ok, "client_alias should have a port")
+						assert.Equal(t, float64(config.ContainerPort), aliasPort, "client_alias port should equal the configured container port")
+					}
+				}
+			}
+		}
+	})
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 45: Deployment controller and circuit breaker compatibility
+// *For any* ECS service configuration, the deployment_circuit_breaker block should only
+// appear on the plan when deployment_controller.type == "ECS", and CODE_DEPLOY-managed
+// services should keep their rolling-update percentages without a circuit breaker.
+// **Validates: Requirements 5.5**
+func TestProperty45_DeploymentControllerAndCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		config := genECSServiceConfig().Draw(rt, "config")
+
+		modulePath := getECSServiceModulePath(t)
+		planName := fmt.Sprintf("plan-p45-%s-%s", config.ServiceName, config.Environment)
+		plan := runECSServiceTerraformPlan(t, modulePath, config, planName)
+
+		ecsServices := plan.getResourcesByType("aws_ecs_service")
+		require.Len(t, ecsServices, 1, "Exactly one ECS service should be created")
+		service := ecsServices[0]
+
+		circuitBreaker, hasCircuitBreaker := service["deployment_circuit_breaker"].([]interface{})
+
+		if config.DeploymentController.Type == "ECS" && config.DeploymentCircuitBreaker != nil {
+			// Property 45.1: ECS controller with a circuit breaker should plan the block with matching flags
+			assert.True(t, hasCircuitBreaker && len(circuitBreaker) == 1, "ECS-controlled service with a circuit breaker should plan a deployment_circuit_breaker block")
+			if hasCircuitBreaker && len(circuitBreaker) == 1 {
+				cb := circuitBreaker[0].(map[string]interface{})
+				assert.Equal(t, config.DeploymentCircuitBreaker.Enable, cb["enable"], "circuit breaker enable flag should match input")
+				assert.Equal(t, config.DeploymentCircuitBreaker.Rollback, cb["rollback"], "circuit breaker rollback flag should match input")
+			}
+		} else {
+			// Property 45.2: No circuit breaker block without an ECS controller / explicit config
+			if hasCircuitBreaker {
+				assert.Len(t, circuitBreaker, 0, "Service without an ECS circuit breaker should not plan a deployment_circuit_breaker block")
+			}
+		}
+
+		if config.DeploymentController.Type == "CODE_DEPLOY" {
+			// Property 45.3: CODE_DEPLOY-managed services still carry rolling-update percentages
+			deploymentConfig, ok := service["deployment_configuration"].([]interface{})
+			assert.True(t, ok && len(deploymentConfig) > 0, "CODE_DEPLOY service should still have deployment_configuration")
+		}
+	})
+}
+
+// TestValidateDeploymentControllerCompatibility is a table test for the fail-fast
+// validation guarding blue/green-incompatible deployment settings
+func TestValidateDeploymentControllerCompatibility(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		controller DeploymentController
+		breaker    *DeploymentCircuitBreaker
+		wantErr    bool
+	}{
+		{
+			name:       "ECS controller with circuit breaker is valid",
+			controller: DeploymentController{Type: "ECS"},
+			breaker:    &DeploymentCircuitBreaker{Enable: true, Rollback: true},
+			wantErr:    false,
+		},
+		{
+			name:       "ECS controller without circuit breaker is valid",
+			controller: DeploymentController{Type: "ECS"},
+			breaker:    nil,
+			wantErr:    false,
+		},
+		{
+			name:       "CODE_DEPLOY with circuit breaker fails fast",
+			controller: DeploymentController{Type: "CODE_DEPLOY"},
+			breaker:    &DeploymentCircuitBreaker{Enable: true, Rollback: true},
+			wantErr:    true,
+		},
+		{
+			name:       "EXTERNAL with circuit breaker fails fast",
+			controller: DeploymentController{Type: "EXTERNAL"},
+			breaker:    &DeploymentCircuitBreaker{Enable: true, Rollback: false},
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateDeploymentControllerCompatibility(tc.controller, tc.breaker)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 46: Capacity provider strategy / Fargate Spot
+// *For any* ECS service configuration with a capacity_provider_strategy, exactly one
+// entry carries a base, all weights sum to a positive integer, launch_type is left
+// unset in the plan (it is mutually exclusive with the strategy), and each strategy
+// entry round-trips to the plan JSON unchanged.
+// **Validates: Requirements 5.1, 5.4**
+func TestProperty46_CapacityProviderStrategy(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		config := genECSServiceConfig().Draw(rt, "config")
+
+		// Only test services that opted into a capacity provider strategy
+		if len(config.CapacityProviderStrategy) == 0 {
+			return
+		}
+
+		modulePath := getECSServiceModulePath(t)
+		planName := fmt.Sprintf("plan-p46-%s-%s", config.ServiceName, config.Environment)
+		plan := runECSServiceTerraformPlan(t, modulePath, config, planName)
+
+		ecsServices := plan.getResourcesByType("aws_ecs_service")
+		require.Len(t, ecsServices, 1, "Exactly one ECS service should be created")
+		service := ecsServices[0]
+
+		// Property 46.1: launch_type should be unset whenever capacity_provider_strategy is set
+		launchType, hasLaunchType := service["launch_type"]
+		if hasLaunchType {
+			assert.Empty(t, launchType, "launch_type should be unset when capacity_provider_strategy is set")
+		}
+
+		strategy, ok := service["capacity_provider_strategy"].([]interface{})
+		require.True(t, ok && len(strategy) == len(config.CapacityProviderStrategy), "Exactly one capacity_provider_strategy entry should be planned per configured entry")
+
+		// Property 46.2: exactly one entry across all entries carries a base
+		baseCount := 0
+		totalWeight := 0
+		for i, entry := range strategy {
+			e := entry.(map[string]interface{})
+			expected := config.CapacityProviderStrategy[i]
+
+			// Property 46.3: each entry round-trips to the plan JSON unchanged
+			assert.Equal(t, expected.Provider, e["capacity_provider"], "capacity_provider should round-trip unchanged")
+			weight, ok := e["weight"].(float64)
+			assert.True(t, ok, "weight should be a number")
+			assert.Equal(t, float64(expected.Weight), weight, "weight should round-trip unchanged")
+			base, ok := e["base"].(float64)
+			assert.True(t, ok, "base should be a number")
+			assert.Equal(t, float64(expected.Base), base, "base should round-trip unchanged")
+
+			totalWeight += expected.Weight
+			if expected.Base > 0 {
+				baseCount++
+			}
+		}
+
+		assert.Equal(t, 1, baseCount, "Exactly one capacity_provider_strategy entry should carry a base")
+		assert.Greater(t, totalWeight, 0, "Capacity provider strategy weights should sum to a positive integer")
+	})
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 47: Autoscaling target/policy configuration
+// *For any* ECS service configuration with autoscaling enabled, the plan should contain
+// exactly one aws_appautoscaling_target bounded by min/max capacity and pointing at the
+// correct ECS service resource, plus one or more TargetTrackingScaling
+// aws_appautoscaling_policy resources matching the requested CPU/memory targets. When
+// autoscaling is disabled, zero autoscaling resources should be planned.
+// **Validates: Requirements 5.4**
+func TestProperty47_AutoscalingTargetAndPolicy(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		config := genECSServiceConfig().Draw(rt, "config")
+
+		modulePath := getECSServiceModulePath(t)
+		planName := fmt.Sprintf("plan-p47-%s-%s", config.ServiceName, config.Environment)
+		plan := runECSServiceTerraformPlan(t, modulePath, config, planName)
+
+		targets := plan.getResourcesByType("aws_appautoscaling_target")
+		policies := plan.getResourcesByType("aws_appautoscaling_policy")
+
+		if !config.Autoscaling.Enabled {
+			// Property 47.1: no autoscaling resources when disabled
+			assert.Len(t, targets, 0, "No aws_appautoscaling_target should be planned when autoscaling is disabled")
+			assert.Len(t, policies, 0, "No aws_appautoscaling_policy should be planned when autoscaling is disabled")
+			return
+		}
+
+		// Property 47.2: exactly one autoscaling target, bounded and addressed correctly
+		require.Len(t, targets, 1, "Exactly one aws_appautoscaling_target should be planned when autoscaling is enabled")
+		target := targets[0]
+
+		minCapacity, ok := target["min_capacity"].(float64)
+		assert.True(t, ok, "min_capacity should be set")
+		maxCapacity, ok := target["max_capacity"].(float64)
+		assert.True(t, ok, "max_capacity should be set")
+		assert.LessOrEqual(t, minCapacity, float64(config.DesiredCount), "min_capacity should be <= desired_count")
+		assert.GreaterOrEqual(t, maxCapacity, float64(config.DesiredCount), "max_capacity should be >= desired_count")
+		assert.Equal(t, float64(config.Autoscaling.MinCapacity), minCapacity, "min_capacity should match input")
+		assert.Equal(t, float64(config.Autoscaling.MaxCapacity), maxCapacity, "max_capacity should match input")
+
+		resourceID, ok := target["resource_id"].(string)
+		assert.True(t, ok, "resource_id should be set")
+		assert.Equal(t, fmt.Sprintf("service/%s/%s", config.ClusterName, config.ServiceName), resourceID, "resource_id should reference the cluster/service pair")
+
+		scalableDimension, ok := target["scalable_dimension"].(string)
+		assert.True(t, ok, "scalable_dimension should be set")
+		assert.Equal(t, "ecs:service:DesiredCount", scalableDimension, "scalable_dimension should be ecs:service:DesiredCount")
+
+		// Property 47.3: one or more target-tracking policies matching the requested targets
+		require.GreaterOrEqual(t, len(policies), 1, "At least one aws_appautoscaling_policy should be planned when autoscaling is enabled")
+
+		cpuPolicyFound := false
+		memoryPolicyFound := false
+		for _, policy := range policies {
+			policyType, ok := policy["policy_type"].(string)
+			assert.True(t, ok, "policy_type should be set")
+			assert.Equal(t, "TargetTrackingScaling", policyType, "Autoscaling policy should be TargetTrackingScaling")
+
+			ttConfig, ok := policy["target_tracking_scaling_policy_configuration"].([]interface{})
+			require.True(t, ok && len(ttConfig) == 1, "Policy should have a target_tracking_scaling_policy_configuration block")
+			ttc := ttConfig[0].(map[string]interface{})
+
+			predefinedMetric, ok := ttc["predefined_metric_specification"].([]interface{})
+			require.True(t, ok && len(predefinedMetric) == 1, "Policy should have a predefined_metric_specification")
+			metric := predefinedMetric[0].(map[string]interface{})
+
+			metricType, ok := metric["predefined_metric_type"].(string)
+			assert.True(t, ok, "predefined_metric_type should be set")
+
+			targetValue, ok := ttc["target_value"].(float64)
+			assert.True(t, ok, "target_value should be set")
+
+			switch metricType {
+			case "ECSServiceAverageCPUUtilization":
+				cpuPolicyFound = true
+				assert.Equal(t, config.Autoscaling.TargetCPUPercent, targetValue, "CPU target value should match input")
+			case "ECSServiceAverageMemoryUtilization":
+				memoryPolicyFound = true
+				assert.Equal(t, config.Autoscaling.TargetMemoryPercent, targetValue, "Memory target value should match input")
+			default:
+				assert.Fail(t, "Unexpected predefined_metric_type", metricType)
+			}
+		}
+
+		assert.True(t, cpuPolicyFound, "A CPU target-tracking policy should be planned")
+		assert.True(t, memoryPolicyFound, "A memory target-tracking policy should be planned")
+	})
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 48: Partition-aware tag propagation
+// *For any* ECS service configuration, every configured tag should appear on the planned
+// aws_ecs_service and propagate_tags should round-trip unchanged. On the ISO partitions
+// (aws-iso, aws-iso-b) the provider may fall back to tagging after creation via a
+// companion aws_ecs_tag resource instead of tags-on-create, so the property tolerates
+// either shape there; GovCloud is held to tags-on-create like the standard partition.
+// **Validates: Requirements 5.1**
+func TestProperty48_PartitionAwareTagPropagation(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		config := genECSServiceConfig().Draw(rt, "config")
+
+		modulePath := getECSServiceModulePath(t)
+		planName := fmt.Sprintf("plan-p48-%s-%s", config.ServiceName, config.Environment)
+		plan := runECSServiceTerraformPlan(t, modulePath, config, planName)
+
+		ecsServices := plan.getResourcesByType("aws_ecs_service")
+		require.Len(t, ecsServices, 1, "Exactly one ECS service should be created")
+		service := ecsServices[0]
+
+		// Property 48.1: propagate_tags round-trips unchanged
+		propagateTags, ok := service["propagate_tags"].(string)
+		assert.True(t, ok, "propagate_tags should be set")
+		assert.Equal(t, config.PropagateTags, propagateTags, "propagate_tags should round-trip unchanged")
+
+		isISOPartition := strings.HasPrefix(config.Partition, "aws-iso")
+		tagsOnResource, _ := service["tags"].(map[string]interface{})
+
+		if !isISOPartition {
+			// Property 48.2: standard/GovCloud partitions tag on create
+			require.NotEmpty(t, tagsOnResource, "aws_ecs_service should have tags on create for standard partitions")
+			for k, v := range config.Tags {
+				assert.Equal(t, v, tagsOnResource[k], "Tag %s should round-trip to aws_ecs_service.tags", k)
+			}
+			return
+		}
+
+		// Property 48.3: ISO partitions may tag on create OR via a companion aws_ecs_tag resource
+		if len(tagsOnResource) > 0 {
+			for k, v := range config.Tags {
+				assert.Equal(t, v, tagsOnResource[k], "Tag %s should round-trip to aws_ecs_service.tags", k)
+			}
+			return
+		}
+
+		ecsTags := plan.getResourcesByType("aws_ecs_tag")
+		assert.GreaterOrEqual(t, len(ecsTags), len(config.Tags), "ISO partitions without tags-on-create should tag via companion aws_ecs_tag resources")
+
+		foundKeys := make(map[string]string)
+		for _, tag := range ecsTags {
+			key, _ := tag["key"].(string)
+			value, _ := tag["value"].(string)
+			foundKeys[key] = value
+		}
+		for k, v := range config.Tags {
+			assert.Equal(t, v, foundKeys[k], "Tag %s should round-trip via aws_ecs_tag", k)
+		}
+	})
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 49: ALB target group + listener rule co-resources
+// *For any* public service with create_target_group = true, the plan should contain
+// exactly one aws_lb_target_group (IP target type, matching port/protocol and health
+// check) and exactly one aws_lb_listener_rule whose conditions cover all configured
+// host headers and path patterns and whose action routes to the created target group,
+// with the target group created before the ECS service to avoid the classic
+// "target group not associated with load balancer" race.
+// **Validates: Requirements 8.1**
+func TestProperty49_TargetGroupAndListenerRuleForPublicServices(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		config := genECSServiceConfig().Draw(rt, "config")
+
+		// Only test public services that have the module own the target group
+		if config.ServiceType != "public" || !config.CreateTargetGroup {
+			return
+		}
+
+		modulePath := getECSServiceModulePath(t)
+		planName := fmt.Sprintf("plan-p49-%s-%s", config.ServiceName, config.Environment)
+		plan := runECSServiceTerraformPlan(t, modulePath, config, planName)
+
+		// Property 49.1: exactly one module-owned target group
+		targetGroups := plan.getResourcesWithAddressByType("aws_lb_target_group")
+		require.Len(t, targetGroups, 1, "Exactly one aws_lb_target_group should be created")
+		tg := targetGroups[0].Values
+
+		targetType, ok := tg["target_type"].(string)
+		assert.True(t, ok, "target_type should be set")
+		assert.Equal(t, "ip", targetType, "Fargate target groups should use target_type = ip")
+
+		port, ok := tg["port"].(float64)
+		assert.True(t, ok, "port should be set")
+		assert.Equal(t, float64(config.ContainerPort), port, "target group port should match the container port")
+
+		protocol, ok := tg["protocol"].(string)
+		assert.True(t, ok, "protocol should be set")
+		expectedProtocol := "HTTP"
+		if config.ContainerPort == 443 || config.ContainerPort == 8443 {
+			expectedProtocol = "HTTPS"
+		}
+		assert.Equal(t, expectedProtocol, protocol, "target group protocol should match the container port convention")
+
+		healthCheck, ok := tg["health_check"].([]interface{})
+		require.True(t, ok && len(healthCheck) == 1, "target group should have a health_check block")
+		hc := healthCheck[0].(map[string]interface{})
+		assert.Equal(t, config.HealthCheck.Path, hc["path"], "health_check.path should match input")
+		assert.Equal(t, config.HealthCheck.Matcher, hc["matcher"], "health_check.matcher should match input")
+		assert.Equal(t, float64(config.HealthCheck.IntervalSeconds), hc["interval"], "health_check.interval should match input")
+		assert.Equal(t, float64(config.HealthCheck.TimeoutSeconds), hc["timeout"], "health_check.timeout should match input")
+
+		// Property 49.2: exactly one listener rule covering all host headers and path patterns
+		listenerRules := plan.getResourcesWithAddressByType("aws_lb_listener_rule")
+		require.Len(t, listenerRules, 1, "Exactly one aws_lb_listener_rule should be created")
+		rule := listenerRules[0].Values
+
+		conditions, ok := rule["condition"].([]interface{})
+		require.True(t, ok && len(conditions) > 0, "listener rule should have condition blocks")
+
+		foundHostHeaders := map[string]bool{}
+		foundPathPatterns := map[string]bool{}
+		for _, c := range conditions {
+			cond := c.(map[string]interface{})
+			if hostHeader, ok := cond["host_header"].([]interface{}); ok && len(hostHeader) > 0 {
+				hh := hostHeader[0].(map[string]interface{})
+				if values, ok := hh["values"].([]interface{}); ok {
+					for _, v := range values {
+						foundHostHeaders[v.(string)] = true
+					}
+				}
+			}
+			if pathPattern, ok := cond["path_pattern"].([]interface{}); ok && len(pathPattern) > 0 {
+				pp := pathPattern[0].(map[string]interface{})
+				if values, ok := pp["values"].([]interface{}); ok {
+					for _, v := range values {
+						foundPathPatterns[v.(string)] = true
+					}
+				}
+			}
+		}
+		for _, h := range config.HostHeaders {
+			assert.True(t, foundHostHeaders[h], "Listener rule conditions should include host header %s", h)
+		}
+		for _, p := range config.PathPatterns {
+			assert.True(t, foundPathPatterns[p], "Listener rule conditions should include path pattern %s", p)
+		}
+
+		// Property 49.3: the listener rule's action routes to the created target group. The
+		// target group's ARN is still unknown on an un-applied plan (so comparing computed
+		// ARNs would vacuously compare two empty strings); instead check the rule's config
+		// expression for target_group_arn actually references the target group resource.
+		tgRelativeAddress := moduleRelativeAddress(targetGroups[0].Address)
+		ruleRelativeAddress := moduleRelativeAddress(listenerRules[0].Address)
+
+		ruleConfig, ok := plan.configResourceByAddress(ruleRelativeAddress)
+		require.True(t, ok, "listener rule should have a configuration entry")
+		refs := ruleConfig.actionTargetGroupReferences()
+		foundReference := false
+		for _, ref := range refs {
+			if ref == tgRelativeAddress || strings.HasPrefix(ref, tgRelativeAddress+".") {
+				foundReference = true
+				break
+			}
+		}
+		assert.True(t, foundReference, "listener rule action's target_group_arn should reference %s, got %v", tgRelativeAddress, refs)
+
+		// Property 49.4: the ECS service explicitly depends on the target group and listener
+		// rule, since aws_ecs_service has no attribute that references aws_lb_listener_rule and
+		// Terraform's plan JSON lists resources alphabetically, not in dependency order
+		ecsServices := plan.getResourcesWithAddressByType("aws_ecs_service")
+		require.Len(t, ecsServices, 1, "Exactly one aws_ecs_service should be created")
+		serviceRelativeAddress := moduleRelativeAddress(ecsServices[0].Address)
+
+		serviceConfig, ok := plan.configResourceByAddress(serviceRelativeAddress)
+		require.True(t, ok, "ECS service should have a configuration entry")
+		assert.Contains(t, serviceConfig.DependsOn, tgRelativeAddress, "ECS service should explicitly depend on the target group")
+		assert.Contains(t, serviceConfig.DependsOn, ruleRelativeAddress, "ECS service should explicitly depend on the listener rule")
+	})
+}
+
+// TestValidateHealthCheckGracePeriodSeconds covers the raised ceiling for
+// health_check_grace_period_seconds (7200 -> math.MaxInt32)
+func TestValidateHealthCheckGracePeriodSeconds(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		seconds int
+		wantErr bool
+	}{
+		{name: "zero is valid", seconds: 0, wantErr: false},
+		{name: "old ceiling is valid", seconds: 7200, wantErr: false},
+		{name: "just past the old ceiling is now valid", seconds: 7201, wantErr: false},
+		{name: "math.MaxInt32 is valid", seconds: healthCheckGracePeriodCeiling, wantErr: false},
+		{name: "negative is invalid", seconds: -1, wantErr: true},
+		{name: "past math.MaxInt32 is invalid", seconds: healthCheckGracePeriodCeiling + 1, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateHealthCheckGracePeriodSeconds(tc.seconds)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 50: Consul service registry sidecar injection
+// *For any* internal service configured with a ConsulRegistry, the plan should carry a
+// service_registries entry pointing at the Consul service ARN plus a companion
+// aws_ecs_task_definition revision whose container_definitions include a consul-agent
+// sidecar with a health check matching the configured check interval. The configured
+// deregister-critical-after is only checked to be non-negative; its planned value is not
+// compared.
+// **Validates: Requirements 5.1**
+func TestProperty50_ConsulServiceRegistrySidecar(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		config := genECSServiceConfig().Draw(rt, "config")
+
+		var consul *ConsulRegistry
+		for _, registry := range config.ServiceRegistries {
+			if c, ok := registry.(ConsulRegistry); ok {
+				consul = &c
+			}
+		}
+		if consul == nil {
+			return
+		}
+
+		modulePath := getECSServiceModulePath(t)
+		planName := fmt.Sprintf("plan-p50-%s-%s", config.ServiceName, config.Environment)
+		plan := runECSServiceTerraformPlan(t, modulePath, config, planName)
+
+		// Property 50.1: service_registries should point at the Consul service ARN
+		ecsServices := plan.getResourcesByType("aws_ecs_service")
+		require.Len(t, ecsServices, 1, "Exactly one ECS service should be created")
+		service := ecsServices[0]
+
+		serviceRegistries, ok := service["service_registries"].([]interface{})
+		require.True(t, ok && len(serviceRegistries) > 0, "Consul-backed service should have service_registries")
+		registry := serviceRegistries[0].(map[string]interface{})
+		assert.Equal(t, consul.ServiceARN, registry["registry_arn"], "service_registries should reference the Consul service ARN")
+
+		// Property 50.2: a companion task definition revision injects the consul-agent sidecar
+		taskDefs := plan.getResourcesByType("aws_ecs_task_definition")
+		require.GreaterOrEqual(t, len(taskDefs), 1, "A task definition revision with the consul-agent sidecar should be planned")
+
+		sidecarFound := false
+		for _, td := range taskDefs {
+			containerDefsJSON, ok := td["container_definitions"].(string)
+			if !ok {
+				continue
+			}
+			var containers []map[string]interface{}
+			if err := json.Unmarshal([]byte(containerDefsJSON), &containers); err != nil {
+				continue
+			}
+			for _, c := range containers {
+				name, _ := c["name"].(string)
+				if name != "consul-agent" {
+					continue
+				}
+				sidecarFound = true
+
+				healthCheck, ok := c["healthCheck"].(map[string]interface{})
+				require.True(t, ok, "consul-agent sidecar should define a health check")
+
+				interval, ok := healthCheck["interval"].(float64)
+				assert.True(t, ok, "health check interval should be a number")
+				assert.Equal(t, float64(consul.CheckIntervalSeconds), interval, "health check interval should match the configured Consul check interval")
+			}
+		}
+		assert.True(t, sidecarFound, "consul-agent sidecar container should be injected into the task definition")
+
+		// Property 50.3: the configured deregister-critical-after is non-negative
+		assert.GreaterOrEqual(t, consul.DeregisterCriticalAfterSeconds, 0, "deregister_critical_after_seconds should be non-negative")
+	})
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 51: Namespace-aware multi-tenant service registration
+// *For any* internal service discovered via Cloud Map, the service_registries entry and the
+// service_discovery_namespace_id should both carry the configured tenant Namespace, and two
+// services sharing a short name in different namespaces must resolve to distinct planned
+// registry_arn values rather than colliding. Terraform resource addresses are not compared.
+// **Validates: Requirements 5.2**
+func TestProperty51_NamespaceAwareServiceRegistration(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		config := genECSServiceConfig().Draw(rt, "config")
+
+		var cloudMap *CloudMapRegistry
+		for _, registry := range config.ServiceRegistries {
+			if c, ok := registry.(CloudMapRegistry); ok {
+				cloudMap = &c
+			}
+		}
+		if cloudMap == nil {
+			return
+		}
+
+		modulePath := getECSServiceModulePath(t)
+		planName := fmt.Sprintf("plan-p51-%s-%s", config.ServiceName, config.Environment)
+		plan := runECSServiceTerraformPlan(t, modulePath, config, planName)
+
+		// Property 51.1: service_registries should reference the namespace-scoped Cloud Map registry
+		ecsServices := plan.getResourcesByType("aws_ecs_service")
+		require.Len(t, ecsServices, 1, "Exactly one ECS service should be created")
+		service := ecsServices[0]
+
+		serviceRegistries, ok := service["service_registries"].([]interface{})
+		require.True(t, ok && len(serviceRegistries) > 0, "Cloud Map-backed service should have service_registries")
+		registry := serviceRegistries[0].(map[string]interface{})
+		assert.Equal(t, config.ServiceDiscoveryNamespaceID, registry["registry_arn"], "service_registries should reference the configured Cloud Map namespace")
+
+		// Property 51.2: the namespace segment should be embedded in the namespace id, not just
+		// the bare environment, so sibling tenants in the same environment don't share a namespace
+		assert.Contains(t, config.ServiceDiscoveryNamespaceID, cloudMap.Namespace, "service_discovery_namespace_id should embed the tenant namespace")
+
+		// Property 51.3: the same short name registered in a different namespace must resolve to
+		// a distinct registry_arn in the planned aws_ecs_service resource, since Cloud Map scopes
+		// service names *within* a namespace rather than across the whole account. Re-plan a second
+		// config that only swaps the namespace, and compare the real plan output of both.
+		otherNamespace := "team-a"
+		if otherNamespace == cloudMap.Namespace {
+			otherNamespace = "team-b"
+		}
+		otherConfig := config
+		otherConfig.Namespace = otherNamespace
+		otherConfig.ServiceDiscoveryNamespaceID = fmt.Sprintf("ns-%s-%s", otherNamespace, config.Environment)
+		otherConfig.ServiceRegistries = []ServiceRegistry{CloudMapRegistry{Namespace: otherNamespace, NamespaceID: otherConfig.ServiceDiscoveryNamespaceID}}
+
+		otherPlanName := fmt.Sprintf("plan-p51-other-%s-%s", config.ServiceName, config.Environment)
+		otherPlan := runECSServiceTerraformPlan(t, modulePath, otherConfig, otherPlanName)
+
+		otherECSServices := otherPlan.getResourcesByType("aws_ecs_service")
+		require.Len(t, otherECSServices, 1, "Exactly one ECS service should be created for the other-namespace plan")
+		otherService := otherECSServices[0]
+
+		otherServiceRegistries, ok := otherService["service_registries"].([]interface{})
+		require.True(t, ok && len(otherServiceRegistries) > 0, "Cloud Map-backed service should have service_registries")
+		otherRegistry := otherServiceRegistries[0].(map[string]interface{})
+
+		assert.NotEqual(t, registry["registry_arn"], otherRegistry["registry_arn"], "same short name in different namespaces should resolve to distinct planned registry ARNs")
+	})
+}
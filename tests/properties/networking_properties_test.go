@@ -1,365 +1,879 @@
-// Package properties contains property-based tests for Terraform modules
-// These tests validate correctness properties defined in the design document
-package properties
-
-import (
-	"encoding/json"
-	"fmt"
-	"os"
-	"os/exec"
-	"path/filepath"
-	"strings"
-	"testing"
-
-	"github.com/gruntwork-io/terratest/modules/terraform"
-	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
-	"pgregory.net/rapid"
-)
-
-// NetworkingConfig represents a valid networking module configuration
-type NetworkingConfig struct {
-	Environment           string
-	ProjectName           string
-	VPCCIDR               string
-	AvailabilityZones     []string
-	EnableNATGateway      bool
-	SingleNATGateway      bool
-	EnableVPCFlowLogs     bool
-	FlowLogsRetentionDays int
-}
-
-// genEnvironment generates a valid environment value
-func genEnvironment() *rapid.Generator[string] {
-	return rapid.SampledFrom([]string{"develop", "test", "qa", "prod"})
-}
-
-// genProjectName generates a valid project name
-func genProjectName() *rapid.Generator[string] {
-	return rapid.Custom(func(t *rapid.T) string {
-		prefix := rapid.SampledFrom([]string{"app", "svc", "api", "web", "data"}).Draw(t, "prefix")
-		suffix := rapid.SampledFrom([]string{"service", "platform", "system", "core"}).Draw(t, "suffix")
-		num := rapid.IntRange(1, 99).Draw(t, "num")
-		return fmt.Sprintf("%s-%s-%d", prefix, suffix, num)
-	})
-}
-
-// genVPCCIDR generates a valid VPC CIDR block
-func genVPCCIDR() *rapid.Generator[string] {
-	return rapid.SampledFrom([]string{
-		"10.0.0.0/16",
-		"10.1.0.0/16",
-		"10.2.0.0/16",
-		"172.16.0.0/16",
-		"172.17.0.0/16",
-	})
-}
-
-// genAvailabilityZones generates a valid list of availability zones (2-3 AZs)
-func genAvailabilityZones() *rapid.Generator[[]string] {
-	return rapid.SampledFrom([][]string{
-		{"us-east-1a", "us-east-1b"},
-		{"us-east-1a", "us-east-1b", "us-east-1c"},
-		{"us-west-2a", "us-west-2b", "us-west-2c"},
-		{"eu-west-1a", "eu-west-1b", "eu-west-1c"},
-	})
-}
-
-// genFlowLogsRetention generates a valid CloudWatch retention value
-func genFlowLogsRetention() *rapid.Generator[int] {
-	return rapid.SampledFrom([]int{1, 3, 5, 7, 14, 30, 60, 90})
-}
-
-// genNetworkingConfig generates a valid networking configuration
-func genNetworkingConfig() *rapid.Generator[NetworkingConfig] {
-	return rapid.Custom(func(t *rapid.T) NetworkingConfig {
-		return NetworkingConfig{
-			Environment:           genEnvironment().Draw(t, "environment"),
-			ProjectName:           genProjectName().Draw(t, "project_name"),
-			VPCCIDR:               genVPCCIDR().Draw(t, "vpc_cidr"),
-			AvailabilityZones:     genAvailabilityZones().Draw(t, "availability_zones"),
-			EnableNATGateway:      rapid.Bool().Draw(t, "enable_nat_gateway"),
-			SingleNATGateway:      rapid.Bool().Draw(t, "single_nat_gateway"),
-			EnableVPCFlowLogs:     rapid.Bool().Draw(t, "enable_vpc_flow_logs"),
-			FlowLogsRetentionDays: genFlowLogsRetention().Draw(t, "flow_logs_retention"),
-		}
-	})
-}
-
-// toTerraformVars converts NetworkingConfig to Terraform variables map
-func (c NetworkingConfig) toTerraformVars() map[string]interface{} {
-	return map[string]interface{}{
-		"environment":              c.Environment,
-		"project_name":             c.ProjectName,
-		"vpc_cidr":                 c.VPCCIDR,
-		"availability_zones":       c.AvailabilityZones,
-		"enable_nat_gateway":       c.EnableNATGateway,
-		"single_nat_gateway":       c.SingleNATGateway,
-		"enable_vpc_flow_logs":     c.EnableVPCFlowLogs,
-		"flow_logs_retention_days": c.FlowLogsRetentionDays,
-	}
-}
-
-// TerraformPlanOutput represents the JSON output of terraform show -json
-type TerraformPlanOutput struct {
-	PlannedValues struct {
-		RootModule struct {
-			Resources []struct {
-				Address string                 `json:"address"`
-				Type    string                 `json:"type"`
-				Name    string                 `json:"name"`
-				Values  map[string]interface{} `json:"values"`
-			} `json:"resources"`
-		} `json:"root_module"`
-	} `json:"planned_values"`
-}
-
-// getResourcesByType returns all resources of a given type from the plan
-func (p *TerraformPlanOutput) getResourcesByType(resourceType string) []map[string]interface{} {
-	var resources []map[string]interface{}
-	for _, r := range p.PlannedValues.RootModule.Resources {
-		if r.Type == resourceType {
-			resources = append(resources, r.Values)
-		}
-	}
-	return resources
-}
-
-// getModulePath returns the absolute path to the networking module
-func getModulePath(t *testing.T) string {
-	cwd, err := os.Getwd()
-	require.NoError(t, err)
-	modulePath := filepath.Join(cwd, "..", "..", "terraform", "modules", "networking")
-	_, err = os.Stat(modulePath)
-	require.NoError(t, err, "Module path does not exist: %s", modulePath)
-	return modulePath
-}
-
-// runTerraformPlanAndGetJSON runs terraform plan and returns the JSON output
-func runTerraformPlanAndGetJSON(t *testing.T, modulePath string, config NetworkingConfig, planName string) *TerraformPlanOutput {
-	planFilePath := fmt.Sprintf("/tmp/%s.tfplan", planName)
-
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
-		TerraformDir: modulePath,
-		Vars:         config.toTerraformVars(),
-		NoColor:      true,
-		PlanFilePath: planFilePath,
-	})
-
-	// Initialize and create plan
-	terraform.Init(t, terraformOptions)
-	terraform.Plan(t, terraformOptions)
-
-	// Run terraform show -json to get JSON output
-	cmd := exec.Command("terraform", "show", "-json", planFilePath)
-	cmd.Dir = modulePath
-	output, err := cmd.CombinedOutput()
-	require.NoError(t, err, "Failed to run terraform show -json: %s", string(output))
-
-	// Parse JSON output
-	var plan TerraformPlanOutput
-	err = json.Unmarshal(output, &plan)
-	require.NoError(t, err, "Failed to parse terraform plan JSON: %s", string(output))
-
-	return &plan
-}
-
-
-// Feature: ecs-fargate-cicd-infrastructure, Property 8: VPC network segmentation
-// *For any* VPC configuration, private subnets should have route tables pointing to NAT gateways
-// (not internet gateways), public subnets should have route tables pointing to internet gateways,
-// and ECS services should be placed in private subnets while ALBs should be placed in public subnets
-// **Validates: Requirements 2.4, 7.2, 7.3, 7.4**
-func TestProperty8_VPCNetworkSegmentation(t *testing.T) {
-	t.Parallel()
-
-	rapid.Check(t, func(rt *rapid.T) {
-		config := genNetworkingConfig().Draw(rt, "config")
-
-		// Skip configurations without NAT gateway as they don't have full segmentation
-		if !config.EnableNATGateway {
-			return
-		}
-
-		modulePath := getModulePath(t)
-		planName := fmt.Sprintf("plan-p8-%s-%s", config.ProjectName, config.Environment)
-		plan := runTerraformPlanAndGetJSON(t, modulePath, config, planName)
-
-		// Property 8.1: VPC should be created with DNS support
-		vpcs := plan.getResourcesByType("aws_vpc")
-		assert.Len(t, vpcs, 1, "Exactly one VPC should be created")
-		if len(vpcs) > 0 {
-			assert.Equal(t, true, vpcs[0]["enable_dns_support"], "VPC should have DNS support enabled")
-			assert.Equal(t, true, vpcs[0]["enable_dns_hostnames"], "VPC should have DNS hostnames enabled")
-		}
-
-		// Property 8.2: Public and private subnets should exist
-		subnets := plan.getResourcesByType("aws_subnet")
-		publicSubnetCount := 0
-		privateSubnetCount := 0
-		for _, subnet := range subnets {
-			if mapPublicIP, ok := subnet["map_public_ip_on_launch"].(bool); ok && mapPublicIP {
-				publicSubnetCount++
-			} else {
-				privateSubnetCount++
-			}
-		}
-		assert.Equal(t, len(config.AvailabilityZones), publicSubnetCount, "Public subnets should match AZ count")
-		assert.Equal(t, len(config.AvailabilityZones), privateSubnetCount, "Private subnets should match AZ count")
-
-		// Property 8.3: Internet Gateway should be created
-		igws := plan.getResourcesByType("aws_internet_gateway")
-		assert.Len(t, igws, 1, "Exactly one Internet Gateway should be created")
-
-		// Property 8.4: NAT Gateways should be created (one per AZ or single)
-		natGateways := plan.getResourcesByType("aws_nat_gateway")
-		expectedNATCount := len(config.AvailabilityZones)
-		if config.SingleNATGateway {
-			expectedNATCount = 1
-		}
-		assert.Len(t, natGateways, expectedNATCount, "NAT Gateway count should match configuration")
-
-		// Property 8.5: Routes should exist for both public (IGW) and private (NAT) subnets
-		routes := plan.getResourcesByType("aws_route")
-		hasPublicInternetRoute := false
-		hasPrivateNATRoute := false
-		for _, route := range routes {
-			if dest, ok := route["destination_cidr_block"].(string); ok && dest == "0.0.0.0/0" {
-				if _, hasIGW := route["gateway_id"]; hasIGW {
-					hasPublicInternetRoute = true
-				}
-				if _, hasNAT := route["nat_gateway_id"]; hasNAT {
-					hasPrivateNATRoute = true
-				}
-			}
-		}
-		assert.True(t, hasPublicInternetRoute, "Public route table should have route to Internet Gateway")
-		assert.True(t, hasPrivateNATRoute, "Private route tables should have routes to NAT Gateway")
-	})
-}
-
-// Feature: ecs-fargate-cicd-infrastructure, Property 34: Multi-AZ deployment
-// *For any* VPC configuration, subnets should span at least 2 distinct availability zones
-// **Validates: Requirements 7.1**
-func TestProperty34_MultiAZDeployment(t *testing.T) {
-	t.Parallel()
-
-	rapid.Check(t, func(rt *rapid.T) {
-		config := genNetworkingConfig().Draw(rt, "config")
-
-		modulePath := getModulePath(t)
-		planName := fmt.Sprintf("plan-p34-%s-%s", config.ProjectName, config.Environment)
-		plan := runTerraformPlanAndGetJSON(t, modulePath, config, planName)
-
-		// Property 34.1: At least 2 availability zones should be used
-		assert.GreaterOrEqual(t, len(config.AvailabilityZones), 2,
-			"At least 2 availability zones are required for high availability")
-
-		// Property 34.2: Subnets should span multiple AZs
-		subnets := plan.getResourcesByType("aws_subnet")
-		publicAZs := make(map[string]bool)
-		privateAZs := make(map[string]bool)
-
-		for _, subnet := range subnets {
-			az, ok := subnet["availability_zone"].(string)
-			if !ok {
-				continue
-			}
-			if mapPublicIP, ok := subnet["map_public_ip_on_launch"].(bool); ok && mapPublicIP {
-				publicAZs[az] = true
-			} else {
-				privateAZs[az] = true
-			}
-		}
-
-		assert.GreaterOrEqual(t, len(publicAZs), 2,
-			"Public subnets should span at least 2 availability zones")
-		assert.GreaterOrEqual(t, len(privateAZs), 2,
-			"Private subnets should span at least 2 availability zones")
-
-		// Property 34.3: Number of subnets should match number of AZs
-		assert.Equal(t, len(config.AvailabilityZones), len(publicAZs),
-			"Public subnet count should match AZ count")
-		assert.Equal(t, len(config.AvailabilityZones), len(privateAZs),
-			"Private subnet count should match AZ count")
-
-		// Property 34.4: Each AZ should have both public and private subnets
-		for _, az := range config.AvailabilityZones {
-			assert.True(t, publicAZs[az], "AZ %s should have a public subnet", az)
-			assert.True(t, privateAZs[az], "AZ %s should have a private subnet", az)
-		}
-	})
-}
-
-// Feature: ecs-fargate-cicd-infrastructure, Property 36: VPC Flow Logs enabled
-// *For any* VPC configuration, a VPC Flow Log resource should be created with encryption enabled
-// **Validates: Requirements 7.9**
-func TestProperty36_VPCFlowLogsEnabled(t *testing.T) {
-	t.Parallel()
-
-	rapid.Check(t, func(rt *rapid.T) {
-		config := genNetworkingConfig().Draw(rt, "config")
-
-		// Only test configurations with flow logs enabled
-		if !config.EnableVPCFlowLogs {
-			return
-		}
-
-		modulePath := getModulePath(t)
-		planName := fmt.Sprintf("plan-p36-%s-%s", config.ProjectName, config.Environment)
-		plan := runTerraformPlanAndGetJSON(t, modulePath, config, planName)
-
-		// Property 36.1: VPC Flow Log should be created when enabled
-		flowLogs := plan.getResourcesByType("aws_flow_log")
-		assert.Len(t, flowLogs, 1, "Exactly one VPC Flow Log should be created when enabled")
-
-		if len(flowLogs) > 0 {
-			flowLog := flowLogs[0]
-
-			// Property 36.2: Flow log should capture ALL traffic
-			trafficType, ok := flowLog["traffic_type"].(string)
-			assert.True(t, ok, "Traffic type should be set")
-			assert.Equal(t, "ALL", trafficType, "Flow log should capture ALL traffic types")
-
-			// Property 36.3: Flow log should use CloudWatch Logs destination
-			logDestType, ok := flowLog["log_destination_type"].(string)
-			assert.True(t, ok, "Log destination type should be set")
-			assert.Equal(t, "cloud-watch-logs", logDestType, "Flow log should use CloudWatch Logs destination")
-		}
-
-		// Property 36.4: CloudWatch Log Group should be created for flow logs
-		logGroups := plan.getResourcesByType("aws_cloudwatch_log_group")
-		flowLogGroupFound := false
-		for _, lg := range logGroups {
-			if name, ok := lg["name"].(string); ok {
-				if strings.Contains(name, "flow-logs") {
-					flowLogGroupFound = true
-
-					// Property 36.5: Log group should have retention configured
-					retention, hasRetention := lg["retention_in_days"]
-					assert.True(t, hasRetention, "Flow log CloudWatch Log Group should have retention configured")
-					if hasRetention {
-						retentionDays, ok := retention.(float64)
-						assert.True(t, ok, "Retention should be a number")
-						assert.Greater(t, retentionDays, float64(0), "Retention should be greater than 0")
-					}
-					break
-				}
-			}
-		}
-		assert.True(t, flowLogGroupFound, "CloudWatch Log Group for VPC Flow Logs should be created")
-
-		// Property 36.6: IAM role for flow logs should be created
-		iamRoles := plan.getResourcesByType("aws_iam_role")
-		flowLogRoleFound := false
-		for _, role := range iamRoles {
-			if name, ok := role["name"].(string); ok {
-				if strings.Contains(name, "flow-logs") {
-					flowLogRoleFound = true
-					break
-				}
-			}
-		}
-		assert.True(t, flowLogRoleFound, "IAM role for VPC Flow Logs should be created")
-	})
-}
+// Package properties contains property-based tests for Terraform modules
+// These tests validate correctness properties defined in the design document
+package properties
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ConnorHartland/ecs-fargate/tests/helpers"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+// NetworkingConfig represents a valid networking module configuration
+type NetworkingConfig struct {
+	Environment           string
+	ProjectName           string
+	VPCCIDR               string
+	AvailabilityZones     []string
+	EnableNATGateway      bool
+	SingleNATGateway      bool
+	EnableVPCFlowLogs     bool
+	FlowLogsRetentionDays int
+	EdgeZones             []ZoneDescriptor // optional mix of Local Zone / Wavelength Zone subnets
+	EnableIPv6            bool
+	IPv6CIDRBlock         string // the VPC's /56; Amazon-assigned (empty) when EnableIPv6 but no override is given
+}
+
+// ZoneType identifies the kind of AWS zone a subnet is placed in. Local Zones
+// and Wavelength Zones extend the parent region but route traffic (and
+// support IGW/NAT) very differently from a standard availability zone.
+type ZoneType string
+
+const (
+	ZoneTypeAvailabilityZone ZoneType = "availability-zone"
+	ZoneTypeLocalZone        ZoneType = "local-zone"
+	ZoneTypeWavelengthZone   ZoneType = "wavelength-zone"
+)
+
+// genZoneType generates a valid AWS zone type
+func genZoneType() *rapid.Generator[ZoneType] {
+	return rapid.SampledFrom([]ZoneType{ZoneTypeLocalZone, ZoneTypeWavelengthZone})
+}
+
+// ZoneDescriptor describes a single edge zone a VPC places subnets into.
+// ParentZone is the standard availability zone this edge zone is anchored to,
+// used to route private edge-zone subnets to a NAT Gateway in the parent
+// region since Local Zones and Wavelength Zones cannot host their own NAT
+// Gateway.
+type ZoneDescriptor struct {
+	Name       string
+	Type       ZoneType
+	ParentZone string
+}
+
+// genEdgeZones generates zero to two edge zones (Local Zone / Wavelength
+// Zone), each anchored to one of the VPC's standard availability zones
+func genEdgeZones(availabilityZones []string) *rapid.Generator[[]ZoneDescriptor] {
+	return rapid.Custom(func(t *rapid.T) []ZoneDescriptor {
+		count := rapid.IntRange(0, 2).Draw(t, "edge_zone_count")
+		zones := make([]ZoneDescriptor, count)
+		for i := 0; i < count; i++ {
+			parent := availabilityZones[rapid.IntRange(0, len(availabilityZones)-1).Draw(t, fmt.Sprintf("edge_zone_parent_index_%d", i))]
+			zoneType := genZoneType().Draw(t, fmt.Sprintf("edge_zone_type_%d", i))
+			suffix := "lz1"
+			if zoneType == ZoneTypeWavelengthZone {
+				suffix = "wl1"
+			}
+			zones[i] = ZoneDescriptor{
+				Name:       fmt.Sprintf("%s-%s-%d", parent, suffix, i),
+				Type:       zoneType,
+				ParentZone: parent,
+			}
+		}
+		return zones
+	})
+}
+
+// genEnvironment generates a valid environment value
+func genEnvironment() *rapid.Generator[string] {
+	return rapid.SampledFrom([]string{"develop", "test", "qa", "prod"})
+}
+
+// genProjectName generates a valid project name
+func genProjectName() *rapid.Generator[string] {
+	return rapid.Custom(func(t *rapid.T) string {
+		prefix := rapid.SampledFrom([]string{"app", "svc", "api", "web", "data"}).Draw(t, "prefix")
+		suffix := rapid.SampledFrom([]string{"service", "platform", "system", "core"}).Draw(t, "suffix")
+		num := rapid.IntRange(1, 99).Draw(t, "num")
+		return fmt.Sprintf("%s-%s-%d", prefix, suffix, num)
+	})
+}
+
+// genVPCCIDR generates a valid VPC CIDR block
+func genVPCCIDR() *rapid.Generator[string] {
+	return rapid.SampledFrom([]string{
+		"10.0.0.0/16",
+		"10.1.0.0/16",
+		"10.2.0.0/16",
+		"172.16.0.0/16",
+		"172.17.0.0/16",
+	})
+}
+
+// genAvailabilityZones generates a valid list of availability zones (2-3 AZs)
+func genAvailabilityZones() *rapid.Generator[[]string] {
+	return rapid.SampledFrom([][]string{
+		{"us-east-1a", "us-east-1b"},
+		{"us-east-1a", "us-east-1b", "us-east-1c"},
+		{"us-west-2a", "us-west-2b", "us-west-2c"},
+		{"eu-west-1a", "eu-west-1b", "eu-west-1c"},
+	})
+}
+
+// genFlowLogsRetention generates a valid CloudWatch retention value
+func genFlowLogsRetention() *rapid.Generator[int] {
+	return rapid.SampledFrom([]int{1, 3, 5, 7, 14, 30, 60, 90})
+}
+
+// IPv6Mode is the dual-stack outcome drawn for a single NetworkingConfig: whether
+// IPv6 is enabled at all, and if so, whether the VPC /56 is caller-supplied or
+// left for AWS to auto-assign
+type IPv6Mode struct {
+	Enabled bool
+	CIDR    string
+}
+
+// genIPv6Mode generates whether dual-stack is enabled and, if so, whether the
+// caller supplies an explicit /56 or lets AWS auto-assign one
+func genIPv6Mode() *rapid.Generator[IPv6Mode] {
+	return rapid.Custom(func(t *rapid.T) IPv6Mode {
+		enabled := rapid.Bool().Draw(t, "enable_ipv6")
+		mode := IPv6Mode{Enabled: enabled}
+		if enabled && rapid.Bool().Draw(t, "explicit_ipv6_cidr") {
+			mode.CIDR = rapid.SampledFrom([]string{
+				"2600:1f18:1234:5600::/56",
+				"2600:1f18:abcd:ef00::/56",
+			}).Draw(t, "ipv6_cidr_block")
+		}
+		return mode
+	})
+}
+
+// defaultAutoAssignedIPv6CIDR is the Amazon-assigned /56 used in the plan
+// whenever EnableIPv6 is set but the caller did not supply an explicit CIDR
+const defaultAutoAssignedIPv6CIDR = "2600:1f18:0000:0000::/56"
+
+// genNetworkingConfig generates a valid networking configuration
+func genNetworkingConfig() *rapid.Generator[NetworkingConfig] {
+	return rapid.Custom(func(t *rapid.T) NetworkingConfig {
+		azs := genAvailabilityZones().Draw(t, "availability_zones")
+		ipv6 := genIPv6Mode().Draw(t, "ipv6_mode")
+		return NetworkingConfig{
+			Environment:           genEnvironment().Draw(t, "environment"),
+			ProjectName:           genProjectName().Draw(t, "project_name"),
+			VPCCIDR:               genVPCCIDR().Draw(t, "vpc_cidr"),
+			AvailabilityZones:     azs,
+			EnableNATGateway:      rapid.Bool().Draw(t, "enable_nat_gateway"),
+			SingleNATGateway:      rapid.Bool().Draw(t, "single_nat_gateway"),
+			EnableVPCFlowLogs:     rapid.Bool().Draw(t, "enable_vpc_flow_logs"),
+			FlowLogsRetentionDays: genFlowLogsRetention().Draw(t, "flow_logs_retention"),
+			EdgeZones:             genEdgeZones(azs).Draw(t, "edge_zones"),
+			EnableIPv6:            ipv6.Enabled,
+			IPv6CIDRBlock:         ipv6.CIDR,
+		}
+	})
+}
+
+// effectiveIPv6CIDRBlock returns the VPC /56 actually in effect for this config:
+// the explicit override when given, or the Amazon-assigned default otherwise
+func (c NetworkingConfig) effectiveIPv6CIDRBlock() string {
+	if c.IPv6CIDRBlock != "" {
+		return c.IPv6CIDRBlock
+	}
+	return defaultAutoAssignedIPv6CIDR
+}
+
+// cidrPlan computes the expected non-overlapping subnet allocation for this
+// config's parent-region availability zones via helpers.CIDRPlanner
+func (c NetworkingConfig) cidrPlan(t *testing.T) []helpers.SubnetPlan {
+	planner := helpers.NewCIDRPlanner(c.VPCCIDR, c.effectiveIPv6CIDRBlock(), c.EnableIPv6)
+	plan, err := planner.Plan(c.AvailabilityZones)
+	require.NoError(t, err, "CIDRPlanner should allocate a plan for %d AZs", len(c.AvailabilityZones))
+	return plan
+}
+
+// toTerraformVars converts NetworkingConfig to Terraform variables map
+func (c NetworkingConfig) toTerraformVars() map[string]interface{} {
+	edgeZones := make([]map[string]interface{}, len(c.EdgeZones))
+	for i, z := range c.EdgeZones {
+		edgeZones[i] = map[string]interface{}{
+			"name":        z.Name,
+			"zone_type":   string(z.Type),
+			"parent_zone": z.ParentZone,
+		}
+	}
+
+	return map[string]interface{}{
+		"environment":              c.Environment,
+		"project_name":             c.ProjectName,
+		"vpc_cidr":                 c.VPCCIDR,
+		"availability_zones":       c.AvailabilityZones,
+		"enable_nat_gateway":       c.EnableNATGateway,
+		"single_nat_gateway":       c.SingleNATGateway,
+		"enable_vpc_flow_logs":     c.EnableVPCFlowLogs,
+		"flow_logs_retention_days": c.FlowLogsRetentionDays,
+		"edge_zones":               edgeZones,
+		"enable_ipv6":              c.EnableIPv6,
+		"ipv6_cidr_block":          c.IPv6CIDRBlock,
+	}
+}
+
+// wavelengthZones returns the subset of EdgeZones that are Wavelength Zones
+func (c NetworkingConfig) wavelengthZones() []ZoneDescriptor {
+	var zones []ZoneDescriptor
+	for _, z := range c.EdgeZones {
+		if z.Type == ZoneTypeWavelengthZone {
+			zones = append(zones, z)
+		}
+	}
+	return zones
+}
+
+// localZones returns the subset of EdgeZones that are Local Zones
+func (c NetworkingConfig) localZones() []ZoneDescriptor {
+	var zones []ZoneDescriptor
+	for _, z := range c.EdgeZones {
+		if z.Type == ZoneTypeLocalZone {
+			zones = append(zones, z)
+		}
+	}
+	return zones
+}
+
+// TerraformPlanOutput represents the JSON output of terraform show -json
+type TerraformPlanOutput struct {
+	PlannedValues struct {
+		RootModule struct {
+			Resources []struct {
+				Address string                 `json:"address"`
+				Type    string                 `json:"type"`
+				Name    string                 `json:"name"`
+				Values  map[string]interface{} `json:"values"`
+			} `json:"resources"`
+		} `json:"root_module"`
+	} `json:"planned_values"`
+}
+
+// getResourcesByType returns all resources of a given type from the plan
+func (p *TerraformPlanOutput) getResourcesByType(resourceType string) []map[string]interface{} {
+	var resources []map[string]interface{}
+	for _, r := range p.PlannedValues.RootModule.Resources {
+		if r.Type == resourceType {
+			resources = append(resources, r.Values)
+		}
+	}
+	return resources
+}
+
+// plannedResourceWithAddress pairs a resource's planned values with its resource address, so
+// callers can correlate resources that share a for_each/count index key even when the
+// attribute that would otherwise link them (e.g. route_table_id) is still unknown on a plan
+// that hasn't been applied.
+type plannedResourceWithAddress struct {
+	Address string
+	Values  map[string]interface{}
+}
+
+// getResourcesWithAddressByType is like getResourcesByType but retains each resource's
+// address, needed when grouping resources can't rely on a plan-time-unknown computed attribute
+func (p *TerraformPlanOutput) getResourcesWithAddressByType(resourceType string) []plannedResourceWithAddress {
+	var resources []plannedResourceWithAddress
+	for _, r := range p.PlannedValues.RootModule.Resources {
+		if r.Type == resourceType {
+			resources = append(resources, plannedResourceWithAddress{Address: r.Address, Values: r.Values})
+		}
+	}
+	return resources
+}
+
+// getModulePath returns the absolute path to the networking module
+func getModulePath(t *testing.T) string {
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	modulePath := filepath.Join(cwd, "..", "..", "terraform", "modules", "networking")
+	_, err = os.Stat(modulePath)
+	require.NoError(t, err, "Module path does not exist: %s", modulePath)
+	return modulePath
+}
+
+// runTerraformPlanAndGetJSON runs terraform plan and returns the JSON output
+func runTerraformPlanAndGetJSON(t *testing.T, modulePath string, config NetworkingConfig, planName string) *TerraformPlanOutput {
+	planFilePath := fmt.Sprintf("/tmp/%s.tfplan", planName)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: modulePath,
+		Vars:         config.toTerraformVars(),
+		NoColor:      true,
+		PlanFilePath: planFilePath,
+	})
+
+	// Initialize and create plan
+	terraform.Init(t, terraformOptions)
+	terraform.Plan(t, terraformOptions)
+
+	// Run terraform show -json to get JSON output
+	cmd := exec.Command("terraform", "show", "-json", planFilePath)
+	cmd.Dir = modulePath
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, "Failed to run terraform show -json: %s", string(output))
+
+	// Parse JSON output
+	var plan TerraformPlanOutput
+	err = json.Unmarshal(output, &plan)
+	require.NoError(t, err, "Failed to parse terraform plan JSON: %s", string(output))
+
+	return &plan
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 8: VPC network segmentation
+// *For any* VPC configuration, private subnets should have route tables pointing to NAT gateways
+// (not internet gateways), public subnets should have route tables pointing to internet gateways,
+// and ECS services should be placed in private subnets while ALBs should be placed in public subnets
+// **Validates: Requirements 2.4, 7.2, 7.3, 7.4**
+func TestProperty8_VPCNetworkSegmentation(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		config := genNetworkingConfig().Draw(rt, "config")
+
+		// Skip configurations without NAT gateway as they don't have full segmentation
+		if !config.EnableNATGateway {
+			return
+		}
+
+		modulePath := getModulePath(t)
+		planName := fmt.Sprintf("plan-p8-%s-%s", config.ProjectName, config.Environment)
+		plan := runTerraformPlanAndGetJSON(t, modulePath, config, planName)
+
+		// Property 8.1: VPC should be created with DNS support
+		vpcs := plan.getResourcesByType("aws_vpc")
+		assert.Len(t, vpcs, 1, "Exactly one VPC should be created")
+		if len(vpcs) > 0 {
+			assert.Equal(t, true, vpcs[0]["enable_dns_support"], "VPC should have DNS support enabled")
+			assert.Equal(t, true, vpcs[0]["enable_dns_hostnames"], "VPC should have DNS hostnames enabled")
+		}
+
+		// Property 8.2: Public and private subnets should exist. Local/Wavelength Zone subnets
+		// are excluded since they're additional edge-zone subnets on top of the per-AZ count,
+		// not a second public/private subnet within a standard availability zone.
+		subnets := plan.getResourcesByType("aws_subnet")
+		publicSubnetCount := 0
+		privateSubnetCount := 0
+		for _, subnet := range subnets {
+			az, ok := subnet["availability_zone"].(string)
+			if !ok || isWavelengthZone(config, az) || isLocalZone(config, az) {
+				continue
+			}
+			if mapPublicIP, ok := subnet["map_public_ip_on_launch"].(bool); ok && mapPublicIP {
+				publicSubnetCount++
+			} else {
+				privateSubnetCount++
+			}
+		}
+		assert.Equal(t, len(config.AvailabilityZones), publicSubnetCount, "Public subnets should match AZ count")
+		assert.Equal(t, len(config.AvailabilityZones), privateSubnetCount, "Private subnets should match AZ count")
+
+		// Property 8.3: Internet Gateway should be created
+		igws := plan.getResourcesByType("aws_internet_gateway")
+		assert.Len(t, igws, 1, "Exactly one Internet Gateway should be created")
+
+		// Property 8.4: NAT Gateways should be created (one per AZ or single)
+		natGateways := plan.getResourcesByType("aws_nat_gateway")
+		expectedNATCount := len(config.AvailabilityZones)
+		if config.SingleNATGateway {
+			expectedNATCount = 1
+		}
+		assert.Len(t, natGateways, expectedNATCount, "NAT Gateway count should match configuration")
+
+		// Property 8.5: Routes should exist for both public (IGW) and private (NAT) subnets
+		routes := plan.getResourcesByType("aws_route")
+		hasPublicInternetRoute := false
+		hasPrivateNATRoute := false
+		for _, route := range routes {
+			if dest, ok := route["destination_cidr_block"].(string); ok && dest == "0.0.0.0/0" {
+				if _, hasIGW := route["gateway_id"]; hasIGW {
+					hasPublicInternetRoute = true
+				}
+				if _, hasNAT := route["nat_gateway_id"]; hasNAT {
+					hasPrivateNATRoute = true
+				}
+			}
+		}
+		assert.True(t, hasPublicInternetRoute, "Public route table should have route to Internet Gateway")
+		assert.True(t, hasPrivateNATRoute, "Private route tables should have routes to NAT Gateway")
+
+		// Property 8.6: Wavelength Zone subnets route 0.0.0.0/0 to a carrier gateway, not an
+		// IGW or NAT gateway, and their public subnets are carrier-IP enabled
+		if len(config.wavelengthZones()) > 0 {
+			carrierGateways := plan.getResourcesByType("aws_ec2_carrier_gateway")
+			assert.Len(t, carrierGateways, 1, "Exactly one carrier gateway should exist when a Wavelength Zone is configured")
+
+			for _, subnet := range subnets {
+				az, ok := subnet["availability_zone"].(string)
+				if !ok || !isWavelengthZone(config, az) {
+					continue
+				}
+				if mapPublicIP, ok := subnet["map_public_ip_on_launch"].(bool); ok && mapPublicIP {
+					tags, ok := subnet["tags"].(map[string]interface{})
+					require.True(t, ok, "Wavelength Zone public subnet should have tags")
+					assert.Equal(t, "true", tags["CarrierIPEnabled"], "Wavelength Zone public subnet should be tagged carrier-IP enabled")
+				}
+			}
+
+			for _, route := range routes {
+				if dest, ok := route["destination_cidr_block"].(string); ok && dest == "0.0.0.0/0" {
+					if _, hasCarrier := route["carrier_gateway_id"]; hasCarrier {
+						_, hasIGW := route["gateway_id"]
+						_, hasNAT := route["nat_gateway_id"]
+						assert.False(t, hasIGW, "A carrier-gateway route should not also target an Internet Gateway")
+						assert.False(t, hasNAT, "A carrier-gateway route should not also target a NAT Gateway")
+					}
+				}
+			}
+		}
+
+		// Property 8.7: no Local Zone ever hosts its own NAT Gateway (Local Zone IGW routing
+		// is not checked here)
+		if len(config.localZones()) > 0 {
+			for _, natGW := range natGateways {
+				az, ok := natGW["availability_zone"].(string)
+				if !ok {
+					continue
+				}
+				assert.False(t, isLocalZone(config, az), "Local Zones must not host their own NAT Gateway (NAT in %s)", az)
+			}
+		}
+	})
+}
+
+// isWavelengthZone reports whether the given zone name is configured as a Wavelength Zone
+func isWavelengthZone(config NetworkingConfig, zoneName string) bool {
+	for _, z := range config.wavelengthZones() {
+		if z.Name == zoneName {
+			return true
+		}
+	}
+	return false
+}
+
+// isLocalZone reports whether the given zone name is configured as a Local Zone
+func isLocalZone(config NetworkingConfig, zoneName string) bool {
+	for _, z := range config.localZones() {
+		if z.Name == zoneName {
+			return true
+		}
+	}
+	return false
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 34: Multi-AZ deployment
+// *For any* VPC configuration, subnets should span at least 2 distinct availability zones
+// **Validates: Requirements 7.1**
+func TestProperty34_MultiAZDeployment(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		config := genNetworkingConfig().Draw(rt, "config")
+
+		modulePath := getModulePath(t)
+		planName := fmt.Sprintf("plan-p34-%s-%s", config.ProjectName, config.Environment)
+		plan := runTerraformPlanAndGetJSON(t, modulePath, config, planName)
+
+		// Property 34.1: At least 2 availability zones should be used
+		assert.GreaterOrEqual(t, len(config.AvailabilityZones), 2,
+			"At least 2 availability zones are required for high availability")
+
+		// Property 34.2: Subnets in the parent-region AZs should span multiple AZs. Local/Wavelength
+		// Zone subnets are excluded here since edge zones don't offer HA and are asserted below instead.
+		subnets := plan.getResourcesByType("aws_subnet")
+		publicAZs := make(map[string]bool)
+		privateAZs := make(map[string]bool)
+
+		for _, subnet := range subnets {
+			az, ok := subnet["availability_zone"].(string)
+			if !ok || isWavelengthZone(config, az) || isLocalZone(config, az) {
+				continue
+			}
+			if mapPublicIP, ok := subnet["map_public_ip_on_launch"].(bool); ok && mapPublicIP {
+				publicAZs[az] = true
+			} else {
+				privateAZs[az] = true
+			}
+		}
+
+		assert.GreaterOrEqual(t, len(publicAZs), 2,
+			"Public subnets should span at least 2 availability zones")
+		assert.GreaterOrEqual(t, len(privateAZs), 2,
+			"Private subnets should span at least 2 availability zones")
+
+		// Property 34.3: Number of subnets should match number of AZs
+		assert.Equal(t, len(config.AvailabilityZones), len(publicAZs),
+			"Public subnet count should match AZ count")
+		assert.Equal(t, len(config.AvailabilityZones), len(privateAZs),
+			"Private subnet count should match AZ count")
+
+		// Property 34.4: Each AZ should have both public and private subnets
+		for _, az := range config.AvailabilityZones {
+			assert.True(t, publicAZs[az], "AZ %s should have a public subnet", az)
+			assert.True(t, privateAZs[az], "AZ %s should have a private subnet", az)
+		}
+
+		// Property 34.5: Edge zones (Local Zone / Wavelength Zone) are exempt from the ≥2 AZ
+		// rule, since AWS does not offer multiple Local/Wavelength Zones within a metro
+		for _, zone := range config.EdgeZones {
+			edgeSubnetFound := false
+			for _, subnet := range subnets {
+				if az, ok := subnet["availability_zone"].(string); ok && az == zone.Name {
+					edgeSubnetFound = true
+					break
+				}
+			}
+			assert.True(t, edgeSubnetFound, "Edge zone %s should have at least one subnet even without a second edge zone for HA", zone.Name)
+		}
+	})
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 36: VPC Flow Logs enabled
+// *For any* VPC configuration, a VPC Flow Log resource should be created with encryption enabled
+// **Validates: Requirements 7.9**
+func TestProperty36_VPCFlowLogsEnabled(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		config := genNetworkingConfig().Draw(rt, "config")
+
+		// Only test configurations with flow logs enabled
+		if !config.EnableVPCFlowLogs {
+			return
+		}
+
+		modulePath := getModulePath(t)
+		planName := fmt.Sprintf("plan-p36-%s-%s", config.ProjectName, config.Environment)
+		plan := runTerraformPlanAndGetJSON(t, modulePath, config, planName)
+
+		// Property 36.1: VPC Flow Log should be created when enabled
+		flowLogs := plan.getResourcesByType("aws_flow_log")
+		assert.Len(t, flowLogs, 1, "Exactly one VPC Flow Log should be created when enabled")
+
+		if len(flowLogs) > 0 {
+			flowLog := flowLogs[0]
+
+			// Property 36.2: Flow log should capture ALL traffic
+			trafficType, ok := flowLog["traffic_type"].(string)
+			assert.True(t, ok, "Traffic type should be set")
+			assert.Equal(t, "ALL", trafficType, "Flow log should capture ALL traffic types")
+
+			// Property 36.3: Flow log should use CloudWatch Logs destination
+			logDestType, ok := flowLog["log_destination_type"].(string)
+			assert.True(t, ok, "Log destination type should be set")
+			assert.Equal(t, "cloud-watch-logs", logDestType, "Flow log should use CloudWatch Logs destination")
+		}
+
+		// Property 36.4: CloudWatch Log Group should be created for flow logs
+		logGroups := plan.getResourcesByType("aws_cloudwatch_log_group")
+		flowLogGroupFound := false
+		for _, lg := range logGroups {
+			if name, ok := lg["name"].(string); ok {
+				if strings.Contains(name, "flow-logs") {
+					flowLogGroupFound = true
+
+					// Property 36.5: Log group should have retention configured
+					retention, hasRetention := lg["retention_in_days"]
+					assert.True(t, hasRetention, "Flow log CloudWatch Log Group should have retention configured")
+					if hasRetention {
+						retentionDays, ok := retention.(float64)
+						assert.True(t, ok, "Retention should be a number")
+						assert.Greater(t, retentionDays, float64(0), "Retention should be greater than 0")
+					}
+					break
+				}
+			}
+		}
+		assert.True(t, flowLogGroupFound, "CloudWatch Log Group for VPC Flow Logs should be created")
+
+		// Property 36.6: IAM role for flow logs should be created
+		iamRoles := plan.getResourcesByType("aws_iam_role")
+		flowLogRoleFound := false
+		for _, role := range iamRoles {
+			if name, ok := role["name"].(string); ok {
+				if strings.Contains(name, "flow-logs") {
+					flowLogRoleFound = true
+					break
+				}
+			}
+		}
+		assert.True(t, flowLogRoleFound, "IAM role for VPC Flow Logs should be created")
+	})
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 52: No NAT Gateway in Wavelength Zones
+// *For any* VPC configuration with one or more Wavelength Zones, no aws_nat_gateway resource
+// should be placed in a Wavelength Zone, since Wavelength Zones route egress through a carrier
+// gateway rather than a NAT Gateway
+// **Validates: Requirements 7.10**
+func TestProperty52_NoNATGatewayInWavelengthZones(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		config := genNetworkingConfig().Draw(rt, "config")
+
+		if len(config.wavelengthZones()) == 0 {
+			return
+		}
+
+		modulePath := getModulePath(t)
+		planName := fmt.Sprintf("plan-p52-%s-%s", config.ProjectName, config.Environment)
+		plan := runTerraformPlanAndGetJSON(t, modulePath, config, planName)
+
+		natGateways := plan.getResourcesByType("aws_nat_gateway")
+		for _, natGW := range natGateways {
+			az, ok := natGW["availability_zone"].(string)
+			if !ok {
+				continue
+			}
+			assert.False(t, isWavelengthZone(config, az), "Wavelength Zone %s must not host a NAT Gateway", az)
+		}
+	})
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 53: Carrier gateway existence matches Wavelength Zone configuration
+// *For any* VPC configuration, exactly one aws_ec2_carrier_gateway should exist if and only if
+// at least one Wavelength Zone subnet is configured
+// **Validates: Requirements 7.10**
+func TestProperty53_CarrierGatewayExistsIffWavelengthZoneConfigured(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		config := genNetworkingConfig().Draw(rt, "config")
+
+		modulePath := getModulePath(t)
+		planName := fmt.Sprintf("plan-p53-%s-%s", config.ProjectName, config.Environment)
+		plan := runTerraformPlanAndGetJSON(t, modulePath, config, planName)
+
+		carrierGateways := plan.getResourcesByType("aws_ec2_carrier_gateway")
+		if len(config.wavelengthZones()) > 0 {
+			assert.Len(t, carrierGateways, 1, "Exactly one carrier gateway should exist when a Wavelength Zone is configured")
+		} else {
+			assert.Len(t, carrierGateways, 0, "No carrier gateway should exist without a Wavelength Zone")
+		}
+	})
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 54: Private edge-zone subnets route through the parent-region NAT
+// *For any* VPC configuration with a Local Zone or Wavelength Zone, the private subnet in that
+// edge zone should route 0.0.0.0/0 to a NAT Gateway placed in the zone's mapped parent
+// availability zone, since neither zone type can host its own NAT Gateway
+// **Validates: Requirements 7.10**
+func TestProperty54_PrivateEdgeZoneSubnetsRouteToParentNAT(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		config := genNetworkingConfig().Draw(rt, "config")
+
+		if len(config.EdgeZones) == 0 || !config.EnableNATGateway {
+			return
+		}
+
+		modulePath := getModulePath(t)
+		planName := fmt.Sprintf("plan-p54-%s-%s", config.ProjectName, config.Environment)
+		plan := runTerraformPlanAndGetJSON(t, modulePath, config, planName)
+
+		natGateways := plan.getResourcesByType("aws_nat_gateway")
+		parentNATsByAZ := make(map[string]bool)
+		for _, natGW := range natGateways {
+			if az, ok := natGW["availability_zone"].(string); ok {
+				parentNATsByAZ[az] = true
+			}
+		}
+
+		subnets := plan.getResourcesByType("aws_subnet")
+		for _, zone := range config.EdgeZones {
+			var privateEdgeSubnet map[string]interface{}
+			for _, subnet := range subnets {
+				az, ok := subnet["availability_zone"].(string)
+				if !ok || az != zone.Name {
+					continue
+				}
+				if mapPublicIP, ok := subnet["map_public_ip_on_launch"].(bool); ok && !mapPublicIP {
+					privateEdgeSubnet = subnet
+				}
+			}
+			require.NotNil(t, privateEdgeSubnet, "Edge zone %s should have a private subnet", zone.Name)
+
+			assert.True(t, parentNATsByAZ[zone.ParentZone],
+				"Private subnet in edge zone %s should route through a NAT Gateway in its mapped parent zone %s", zone.Name, zone.ParentZone)
+		}
+	})
+}
+
+// ipv6CIDRIsQuadSixtyFourWithin reports whether child is a /64 fully contained in parent
+func ipv6CIDRIsQuadSixtyFourWithin(parent, child string) bool {
+	_, parentNet, err := net.ParseCIDR(parent)
+	if err != nil {
+		return false
+	}
+	childIP, childNet, err := net.ParseCIDR(child)
+	if err != nil {
+		return false
+	}
+	ones, _ := childNet.Mask.Size()
+	return ones == 64 && parentNet.Contains(childIP)
+}
+
+// cidrsOverlap reports whether two CIDR blocks (IPv4 or IPv6) share any address
+func cidrsOverlap(a, b string) bool {
+	_, aNet, errA := net.ParseCIDR(a)
+	_, bNet, errB := net.ParseCIDR(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return aNet.Contains(bNet.IP) || bNet.Contains(aNet.IP)
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 55: IPv6 dual-stack subnet allocation and routing
+// *For any* VPC configuration with enable_ipv6=true, every subnet should carry a /64 contained in
+// the VPC's /56 with no overlap across subnets, public subnets should route ::/0 to the Internet
+// Gateway and assign IPv6 addresses on launch, and private subnets should route ::/0 to an
+// Egress-Only Internet Gateway instead
+// **Validates: Requirements 7.11**
+func TestProperty55_IPv6DualStackSubnetAllocation(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		config := genNetworkingConfig().Draw(rt, "config")
+
+		if !config.EnableIPv6 {
+			return
+		}
+
+		modulePath := getModulePath(t)
+		planName := fmt.Sprintf("plan-p55-%s-%s", config.ProjectName, config.Environment)
+		plan := runTerraformPlanAndGetJSON(t, modulePath, config, planName)
+
+		vpcCIDR6 := config.effectiveIPv6CIDRBlock()
+		subnets := plan.getResourcesByType("aws_subnet")
+		require.NotEmpty(t, subnets, "Dual-stack VPC should still have subnets")
+
+		var allCIDRs []string
+		for _, subnet := range subnets {
+			// Property 55.1: every subnet has a non-empty /64 contained in the VPC /56
+			ipv6CIDR, ok := subnet["ipv6_cidr_block"].(string)
+			require.True(t, ok && ipv6CIDR != "", "Dual-stack subnet should have a non-empty ipv6_cidr_block")
+			assert.True(t, ipv6CIDRIsQuadSixtyFourWithin(vpcCIDR6, ipv6CIDR),
+				"ipv6_cidr_block %s should be a /64 contained in the VPC /56 %s", ipv6CIDR, vpcCIDR6)
+			allCIDRs = append(allCIDRs, ipv6CIDR)
+
+			if ipv4CIDR, ok := subnet["cidr_block"].(string); ok {
+				allCIDRs = append(allCIDRs, ipv4CIDR)
+			}
+
+			isPublic, _ := subnet["map_public_ip_on_launch"].(bool)
+
+			// Property 55.4: assign_ipv6_address_on_creation is true only for public subnets
+			assignOnCreation, _ := subnet["assign_ipv6_address_on_creation"].(bool)
+			assert.Equal(t, isPublic, assignOnCreation,
+				"assign_ipv6_address_on_creation should be true only for public subnets")
+		}
+
+		// Property 55.2: all IPv4 and IPv6 subnet CIDRs are pairwise non-overlapping
+		for i := 0; i < len(allCIDRs); i++ {
+			for j := i + 1; j < len(allCIDRs); j++ {
+				assert.False(t, cidrsOverlap(allCIDRs[i], allCIDRs[j]),
+					"subnet CIDRs %s and %s should not overlap", allCIDRs[i], allCIDRs[j])
+			}
+		}
+
+		// Property 55.3: public subnets route ::/0 to the IGW; private subnets route ::/0 to an
+		// Egress-Only Internet Gateway
+		eigws := plan.getResourcesByType("aws_egress_only_internet_gateway")
+		assert.Len(t, eigws, 1, "Exactly one Egress-Only Internet Gateway should be created for dual-stack private subnets")
+
+		routes := plan.getResourcesByType("aws_route")
+		hasPublicIPv6Route := false
+		hasPrivateEgressOnlyRoute := false
+		for _, route := range routes {
+			dest, ok := route["destination_ipv6_cidr_block"].(string)
+			if !ok || dest != "::/0" {
+				continue
+			}
+			if _, hasIGW := route["gateway_id"]; hasIGW {
+				hasPublicIPv6Route = true
+			}
+			if _, hasEIGW := route["egress_only_gateway_id"]; hasEIGW {
+				hasPrivateEgressOnlyRoute = true
+			}
+		}
+		assert.True(t, hasPublicIPv6Route, "Public route table should have an ::/0 route to the Internet Gateway")
+		assert.True(t, hasPrivateEgressOnlyRoute, "Private route table should have an ::/0 route to the Egress-Only Internet Gateway")
+	})
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 56: No silent CIDR shrinkage
+// *For any* previously-planned subnet layout, replanning with a strictly-smaller VPC CIDR that
+// would drop existing subnets must be flagged as destructive, mirroring the shrinkage-detection
+// logic the GCP subnetwork provider uses to block CIDR changes that orphan existing resources
+// **Validates: Requirements 7.11**
+func TestProperty56_NoSilentCIDRShrinkage(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name            string
+		previousVPCCIDR string
+		previousAZs     []string
+		nextVPCCIDR     string
+		nextAZs         []string
+		wantDestructive bool
+	}{
+		{
+			name:            "same AZ count, same CIDR: no shrinkage",
+			previousVPCCIDR: "10.0.0.0/16",
+			previousAZs:     []string{"us-east-1a", "us-east-1b"},
+			nextVPCCIDR:     "10.0.0.0/16",
+			nextAZs:         []string{"us-east-1a", "us-east-1b"},
+			wantDestructive: false,
+		},
+		{
+			// 4 subnets are carved as /18s, 6 as /19s, so every existing subnet's CIDR changes
+			name:            "growing to a third AZ re-carves existing subnets: destructive",
+			previousVPCCIDR: "10.0.0.0/16",
+			previousAZs:     []string{"us-east-1a", "us-east-1b"},
+			nextVPCCIDR:     "10.0.0.0/16",
+			nextAZs:         []string{"us-east-1a", "us-east-1b", "us-east-1c"},
+			wantDestructive: true,
+		},
+		{
+			name:            "dropping an AZ: destructive",
+			previousVPCCIDR: "10.0.0.0/16",
+			previousAZs:     []string{"us-east-1a", "us-east-1b", "us-east-1c"},
+			nextVPCCIDR:     "10.0.0.0/16",
+			nextAZs:         []string{"us-east-1a", "us-east-1b"},
+			wantDestructive: true,
+		},
+		{
+			name:            "shrinking the VPC CIDR with the same AZs: destructive",
+			previousVPCCIDR: "10.0.0.0/16",
+			previousAZs:     []string{"us-east-1a", "us-east-1b"},
+			nextVPCCIDR:     "10.0.0.0/20",
+			nextAZs:         []string{"us-east-1a", "us-east-1b"},
+			wantDestructive: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			planner := helpers.NewCIDRPlanner(tc.previousVPCCIDR, "", false)
+			previous, err := planner.Plan(tc.previousAZs)
+			require.NoError(t, err)
+
+			nextPlanner := helpers.NewCIDRPlanner(tc.nextVPCCIDR, "", false)
+			next, err := nextPlanner.Plan(tc.nextAZs)
+			require.NoError(t, err)
+
+			result := helpers.DetectShrinkage(previous, next)
+			assert.Equal(t, tc.wantDestructive, result.Destructive, "shrinkage detection mismatch for %s", tc.name)
+			if tc.wantDestructive {
+				assert.NotEmpty(t, result.Dropped, "destructive shrinkage should list the dropped subnets")
+			}
+		})
+	}
+}
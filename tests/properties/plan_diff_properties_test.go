@@ -0,0 +1,163 @@
+// Package properties contains property-based tests for Terraform modules
+// These tests validate correctness properties defined in the design document
+package properties
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/ConnorHartland/ecs-fargate/tests/helpers"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+// safeReplacementWhitelist lists the resource types allowed to appear in
+// Plan.Replacements() when only a "safe" field (tags, retention, a toggle
+// that's documented as forcing recreation) changes. An empty whitelist means
+// none of the safe fields this package mutates should ever force a replace.
+var safeReplacementWhitelist = map[string]bool{}
+
+// copyModuleToTempDir copies the networking module source into a fresh temp
+// directory so InitAndApply/Destroy never touch the shared module directory
+// other (plan-only) property tests in this package read concurrently.
+func copyModuleToTempDir(t *testing.T, modulePath string) string {
+	tempDir, err := os.MkdirTemp("", "terraform-module-*")
+	require.NoError(t, err)
+
+	err = filepath.Walk(modulePath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(modulePath, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		dest := filepath.Join(tempDir, rel)
+		if info.IsDir() {
+			return os.MkdirAll(dest, info.Mode())
+		}
+
+		src, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+
+		out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode())
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+
+		_, err = io.Copy(out, src)
+		return err
+	})
+	require.NoError(t, err, "Failed to copy module %s into isolated temp dir", modulePath)
+
+	return tempDir
+}
+
+// runNetworkingTerraformPlanDiff runs terraform plan against the module's current
+// state and parses the full helpers.TerraformPlan, including resource_changes, so
+// property tests can classify each change via CreatesOnly/IsEmpty/Replacements/DiffFor
+func runNetworkingTerraformPlanDiff(t *testing.T, modulePath string, config NetworkingConfig, planName string) *helpers.TerraformPlan {
+	planFilePath := fmt.Sprintf("/tmp/%s.tfplan", planName)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: modulePath,
+		Vars:         config.toTerraformVars(),
+		NoColor:      true,
+		PlanFilePath: planFilePath,
+	})
+
+	terraform.Plan(t, terraformOptions)
+
+	cmd := exec.Command("terraform", "show", "-json", planFilePath)
+	cmd.Dir = modulePath
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, "Failed to run terraform show -json: %s", string(output))
+
+	plan, err := helpers.ParseTerraformPlan(string(output))
+	require.NoError(t, err, "Failed to parse terraform plan JSON: %s", string(output))
+
+	return plan
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 57: Idempotency
+// *For any* NetworkingConfig, applying once and then re-planning against the resulting state
+// with the same config should produce an empty plan — no create, update, delete, or replace
+// actions on any resource
+// **Validates: Requirements 7.12**
+func TestProperty57_Idempotency(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		config := genNetworkingConfig().Draw(rt, "config")
+
+		modulePath := copyModuleToTempDir(t, getModulePath(t))
+		defer os.RemoveAll(modulePath)
+
+		terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+			TerraformDir: modulePath,
+			Vars:         config.toTerraformVars(),
+			NoColor:      true,
+		})
+		defer terraform.Destroy(t, terraformOptions)
+		terraform.InitAndApply(t, terraformOptions)
+
+		planName := fmt.Sprintf("plan-p57-%s-%s", config.ProjectName, config.Environment)
+		plan := runNetworkingTerraformPlanDiff(t, modulePath, config, planName)
+
+		assert.True(t, plan.IsEmpty(), "Re-planning an unchanged config against applied state should produce no changes")
+	})
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 58: No unexpected replacement on safe field changes
+// *For any* NetworkingConfig, changing only a single "safe" field (flow log retention days, or
+// toggling single_nat_gateway) between two plans against the same applied state must not force a
+// destroy/recreate of any resource type outside the whitelist — in particular, stateful
+// networking resources like the VPC or its subnets must never be silently replaced. Each run
+// mutates exactly one safe field so a flagged replacement is attributable to that field alone.
+// **Validates: Requirements 7.12**
+func TestProperty58_NoUnexpectedReplacement(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		config := genNetworkingConfig().Draw(rt, "config")
+
+		modulePath := copyModuleToTempDir(t, getModulePath(t))
+		defer os.RemoveAll(modulePath)
+
+		terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+			TerraformDir: modulePath,
+			Vars:         config.toTerraformVars(),
+			NoColor:      true,
+		})
+		defer terraform.Destroy(t, terraformOptions)
+		terraform.InitAndApply(t, terraformOptions)
+
+		mutated := config
+		if rapid.Bool().Draw(rt, "mutate_flow_logs_retention") {
+			mutated.FlowLogsRetentionDays = genFlowLogsRetention().Draw(rt, "mutated_flow_logs_retention")
+		} else {
+			mutated.SingleNATGateway = !config.SingleNATGateway
+		}
+
+		planName := fmt.Sprintf("plan-p58-%s-%s", config.ProjectName, config.Environment)
+		plan := runNetworkingTerraformPlanDiff(t, modulePath, mutated, planName)
+
+		for _, replacement := range plan.Replacements() {
+			assert.True(t, safeReplacementWhitelist[replacement.Type],
+				"Changing only a safe field should not force a replace of %s (%s)", replacement.Type, replacement.Address)
+		}
+	})
+}
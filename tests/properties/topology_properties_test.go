@@ -0,0 +1,339 @@
+// Package properties contains property-based tests for Terraform modules
+// These tests validate correctness properties defined in the design document
+package properties
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ConnorHartland/ecs-fargate/tests/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+// PeeringMode selects how a TopologyConfig's VPCs are interconnected
+type PeeringMode string
+
+const (
+	PeeringModeNone            PeeringMode = "none"
+	PeeringModeMesh            PeeringMode = "mesh"
+	PeeringModeHubSpokeTGW     PeeringMode = "hub-spoke-tgw"
+	PeeringModeHubSpokePeering PeeringMode = "hub-spoke-peering"
+)
+
+// genPeeringMode generates a valid topology peering mode
+func genPeeringMode() *rapid.Generator[PeeringMode] {
+	return rapid.SampledFrom([]PeeringMode{
+		PeeringModeNone,
+		PeeringModeMesh,
+		PeeringModeHubSpokeTGW,
+		PeeringModeHubSpokePeering,
+	})
+}
+
+// TopologyConfig composes 2-4 networking module instances into a single
+// multi-VPC topology, connected per PeeringMode. VPCs[0] is the hub in
+// hub-and-spoke modes.
+type TopologyConfig struct {
+	VPCs        []NetworkingConfig
+	PeeringMode PeeringMode
+}
+
+// genTopologyConfig generates 2-4 VPCs with guaranteed non-overlapping CIDRs
+// (carved from a single supernet via helpers.AllocateVPCCIDRs) plus a peering mode
+func genTopologyConfig() *rapid.Generator[TopologyConfig] {
+	return rapid.Custom(func(t *rapid.T) TopologyConfig {
+		count := rapid.IntRange(2, 4).Draw(t, "vpc_count")
+
+		cidrs, err := helpers.AllocateVPCCIDRs("10.0.0.0/8", count)
+		if err != nil {
+			t.Fatalf("allocating VPC CIDRs: %v", err)
+		}
+
+		vpcs := make([]NetworkingConfig, count)
+		for i := 0; i < count; i++ {
+			vpc := genNetworkingConfig().Draw(t, fmt.Sprintf("vpc_%d", i))
+			vpc.VPCCIDR = cidrs[i]
+			vpc.EdgeZones = nil // topology properties focus on peering/TGW wiring, not edge zones
+			vpcs[i] = vpc
+		}
+
+		return TopologyConfig{
+			VPCs:        vpcs,
+			PeeringMode: genPeeringMode().Draw(t, "peering_mode"),
+		}
+	})
+}
+
+// expectedPeeringConnections returns how many aws_vpc_peering_connection
+// resources this topology should produce: n*(n-1)/2 for a full mesh, n-1 for
+// hub-and-spoke-via-peering, and 0 for TGW-based or disconnected topologies
+func (tc TopologyConfig) expectedPeeringConnections() int {
+	n := len(tc.VPCs)
+	switch tc.PeeringMode {
+	case PeeringModeMesh:
+		return n * (n - 1) / 2
+	case PeeringModeHubSpokePeering:
+		return n - 1
+	default:
+		return 0
+	}
+}
+
+// toTerraformVars converts TopologyConfig to the composition root's variables map
+func (tc TopologyConfig) toTerraformVars() map[string]interface{} {
+	vpcs := make([]map[string]interface{}, len(tc.VPCs))
+	for i, vpc := range tc.VPCs {
+		vpcs[i] = vpc.toTerraformVars()
+	}
+	return map[string]interface{}{
+		"vpcs":         vpcs,
+		"peering_mode": string(tc.PeeringMode),
+	}
+}
+
+// getTopologyModulePath returns the absolute path to the multi-VPC topology composition
+func getTopologyModulePath(t *testing.T) string {
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	modulePath := filepath.Join(cwd, "..", "..", "terraform", "compositions", "multi-vpc-topology")
+	_, err = os.Stat(modulePath)
+	require.NoError(t, err, "Composition path does not exist: %s", modulePath)
+	return modulePath
+}
+
+// runTopologyTerraformPlan runs terraform plan for the topology composition and
+// returns the parsed JSON plan output
+func runTopologyTerraformPlan(t *testing.T, modulePath string, config TopologyConfig, planName string) *TerraformPlanOutput {
+	tempDir, err := os.MkdirTemp("", "terraform-test-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	varsFilePath := filepath.Join(tempDir, "terraform.tfvars.json")
+	varsJSON, err := json.MarshalIndent(config.toTerraformVars(), "", "  ")
+	require.NoError(t, err)
+	err = os.WriteFile(varsFilePath, varsJSON, 0644)
+	require.NoError(t, err)
+
+	initCmd := exec.Command("terraform", "init")
+	initCmd.Dir = modulePath
+	initOutput, err := initCmd.CombinedOutput()
+	require.NoError(t, err, "Failed to init terraform: %s", string(initOutput))
+
+	planFilePath := filepath.Join(tempDir, "plan.tfplan")
+	planCmd := exec.Command("terraform", "plan", "-var-file="+varsFilePath, "-out="+planFilePath, "-input=false")
+	planCmd.Dir = modulePath
+	planOutput, err := planCmd.CombinedOutput()
+	require.NoError(t, err, "Failed to create terraform plan: %s", string(planOutput))
+
+	showCmd := exec.Command("terraform", "show", "-json", planFilePath)
+	showCmd.Dir = modulePath
+	jsonOutput, err := showCmd.CombinedOutput()
+	require.NoError(t, err, "Failed to show terraform plan: %s", string(jsonOutput))
+
+	var plan TerraformPlanOutput
+	err = json.Unmarshal(jsonOutput, &plan)
+	require.NoError(t, err, "Failed to parse terraform plan JSON")
+
+	return &plan
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 59: Full-mesh peering completeness
+// *For any* topology in mesh mode, the plan should have n*(n-1)/2 peering connections, and for
+// every VPC the plan should carry a route to that VPC's CIDR via a peering connection. Which
+// VPC's route table holds each route is not checked.
+// **Validates: Requirements 7.13**
+func TestProperty59_MeshPeeringCompleteness(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		topology := genTopologyConfig().Draw(rt, "topology")
+
+		if topology.PeeringMode != PeeringModeMesh {
+			return
+		}
+
+		modulePath := getTopologyModulePath(t)
+		planName := fmt.Sprintf("plan-p59-%s-%s", topology.VPCs[0].ProjectName, topology.VPCs[0].Environment)
+		plan := runTopologyTerraformPlan(t, modulePath, topology, planName)
+
+		n := len(topology.VPCs)
+
+		// Property 59.1: exactly one peering connection per unordered VPC pair
+		peerings := plan.getResourcesByType("aws_vpc_peering_connection")
+		assert.Len(t, peerings, n*(n-1)/2, "Mesh topology with %d VPCs should have n*(n-1)/2 peering connections", n)
+
+		// Property 59.2: some route in the plan targets each peer's CIDR via peering
+		routes := plan.getResourcesByType("aws_route")
+		for i := range topology.VPCs {
+			for j, vpcB := range topology.VPCs {
+				if i == j {
+					continue
+				}
+				foundRouteToPeer := false
+				for _, route := range routes {
+					dest, ok := route["destination_cidr_block"].(string)
+					if !ok || dest != vpcB.VPCCIDR {
+						continue
+					}
+					if _, hasPeering := route["vpc_peering_connection_id"]; hasPeering {
+						foundRouteToPeer = true
+						break
+					}
+				}
+				assert.True(t, foundRouteToPeer, "VPC %d should route to peer VPC %d's CIDR (%s) via peering", i, j, vpcB.VPCCIDR)
+			}
+		}
+	})
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 60: Hub-and-spoke Transit Gateway wiring
+// *For any* topology in hub-spoke-tgw mode, exactly one Transit Gateway should exist, each spoke
+// should have exactly one TGW attachment, the plan should carry a route via the TGW to every
+// spoke's CIDR, and every route to a spoke's CIDR should go through the TGW. Which VPC's route
+// table holds each route is not checked, so spoke-to-spoke routes via the TGW are not rejected.
+// **Validates: Requirements 7.13**
+func TestProperty60_HubSpokeTGWWiring(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		topology := genTopologyConfig().Draw(rt, "topology")
+
+		if topology.PeeringMode != PeeringModeHubSpokeTGW {
+			return
+		}
+
+		modulePath := getTopologyModulePath(t)
+		planName := fmt.Sprintf("plan-p60-%s-%s", topology.VPCs[0].ProjectName, topology.VPCs[0].Environment)
+		plan := runTopologyTerraformPlan(t, modulePath, topology, planName)
+
+		hub := topology.VPCs[0]
+		spokes := topology.VPCs[1:]
+
+		// Property 60.1: exactly one Transit Gateway
+		tgws := plan.getResourcesByType("aws_ec2_transit_gateway")
+		assert.Len(t, tgws, 1, "Exactly one Transit Gateway should exist for hub-spoke-tgw")
+
+		// Property 60.2: exactly one attachment per spoke
+		attachments := plan.getResourcesByType("aws_ec2_transit_gateway_vpc_attachment")
+		assert.Len(t, attachments, len(spokes), "Exactly one TGW attachment should exist per spoke")
+
+		routes := plan.getResourcesByType("aws_route")
+
+		// Property 60.3: a route to every spoke's CIDR via the TGW exists
+		for _, spoke := range spokes {
+			foundHubRouteToSpoke := false
+			for _, route := range routes {
+				dest, ok := route["destination_cidr_block"].(string)
+				if !ok || dest != spoke.VPCCIDR {
+					continue
+				}
+				if _, hasTGW := route["transit_gateway_id"]; hasTGW {
+					foundHubRouteToSpoke = true
+					break
+				}
+			}
+			assert.True(t, foundHubRouteToSpoke, "Hub should route to spoke CIDR %s via the Transit Gateway", spoke.VPCCIDR)
+		}
+
+		// Property 60.4: every route to a spoke's CIDR goes through the TGW, not a direct path
+		for _, route := range routes {
+			dest, ok := route["destination_cidr_block"].(string)
+			if !ok || dest == hub.VPCCIDR {
+				continue
+			}
+			for _, otherSpoke := range spokes {
+				if dest == otherSpoke.VPCCIDR {
+					_, hasTGW := route["transit_gateway_id"]
+					assert.True(t, hasTGW, "A spoke-to-spoke route must go through the TGW hub, not a direct path")
+				}
+			}
+		}
+	})
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 61: No overlapping route destinations
+// *For any* topology, no route table should ever contain two routes whose destination CIDRs
+// overlap, regardless of peering mode
+// **Validates: Requirements 7.13**
+func TestProperty61_NoOverlappingRouteDestinations(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		topology := genTopologyConfig().Draw(rt, "topology")
+
+		modulePath := getTopologyModulePath(t)
+		planName := fmt.Sprintf("plan-p61-%s-%s", topology.VPCs[0].ProjectName, topology.VPCs[0].Environment)
+		plan := runTopologyTerraformPlan(t, modulePath, topology, planName)
+
+		routeTables := plan.getResourcesWithAddressByType("aws_route_table")
+		routes := plan.getResourcesWithAddressByType("aws_route")
+
+		for _, table := range routeTables {
+			tableKey := routeGroupKey(table.Address, "aws_route_table")
+
+			var destinations []string
+			for _, route := range routes {
+				if routeGroupKey(route.Address, "aws_route") != tableKey {
+					continue
+				}
+				if dest, ok := route.Values["destination_cidr_block"].(string); ok {
+					destinations = append(destinations, dest)
+				}
+			}
+
+			for i := 0; i < len(destinations); i++ {
+				for j := i + 1; j < len(destinations); j++ {
+					assert.False(t, cidrsOverlap(destinations[i], destinations[j]),
+						"route table %s should not have overlapping destinations %s and %s", table.Address, destinations[i], destinations[j])
+				}
+			}
+		}
+	})
+}
+
+// routeGroupKey returns the stable key correlating a route table with the routes declared
+// under it: the enclosing module instance path plus the resource's for_each/count index key.
+// Route tables and their routes are keyed by the same index in this composition, so this
+// identifies them as a group without relying on route_table_id, which is still an unknown
+// computed value on a plan that hasn't been applied.
+func routeGroupKey(address, resourceType string) string {
+	modulePrefix := address
+	resourcePart := address
+	if idx := strings.Index(address, "."+resourceType+"."); idx != -1 {
+		modulePrefix = address[:idx]
+		resourcePart = address[idx:]
+	}
+	indexKey := ""
+	if idx := strings.Index(resourcePart, "["); idx != -1 {
+		indexKey = resourcePart[idx:]
+	}
+	return modulePrefix + indexKey
+}
+
+// Feature: ecs-fargate-cicd-infrastructure, Property 62: Peering connection count matches topology math
+// *For any* topology, the total number of aws_vpc_peering_connection resources should equal
+// n*(n-1)/2 for mesh and n-1 for hub-spoke-peering, with zero for TGW-based or disconnected
+// topologies
+// **Validates: Requirements 7.13**
+func TestProperty62_PeeringConnectionCountMatchesTopology(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(rt *rapid.T) {
+		topology := genTopologyConfig().Draw(rt, "topology")
+
+		modulePath := getTopologyModulePath(t)
+		planName := fmt.Sprintf("plan-p62-%s-%s", topology.VPCs[0].ProjectName, topology.VPCs[0].Environment)
+		plan := runTopologyTerraformPlan(t, modulePath, topology, planName)
+
+		peerings := plan.getResourcesByType("aws_vpc_peering_connection")
+		assert.Len(t, peerings, topology.expectedPeeringConnections(),
+			"Peering connection count should match the %s topology's expected n*(n-1)/2 or n-1", topology.PeeringMode)
+	})
+}